@@ -1,19 +1,24 @@
 package all
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jirevwe/compareids/cmd/common"
 	"github.com/jirevwe/compareids/cmd/merge"
 	"github.com/jirevwe/compareids/cmd/root"
+	"github.com/jirevwe/compareids/ids"
 	"github.com/spf13/cobra"
 )
 
 var (
 	// skipMerge is a flag to skip merging the results
 	skipMerge bool
+
+	// encodings are the column types each ID type is stored in
+	encodings []string
 )
 
 // Command represents the all command
@@ -21,31 +26,48 @@ var Command = &cobra.Command{
 	Use:   "all",
 	Short: "Run tests for all ID types",
 	Long: `Run tests for all ID types with the default row counts and merge the results.
-This is equivalent to running the id command for each ID type and then the merge command.`,
+This is equivalent to running the id command for each ID type and then the merge command.
+Use --encodings to also store each ID type as text, BYTEA or UUID, e.g. --encodings native,text,bytea,uuid`,
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := cmd.Context()
-
-		// Get all ID types
-		idTypes := common.GetAllIDTypes()
-		rowCounts := common.GetDefaultRowCounts()
+		if err := runTests(cmd.Context()); err != nil {
+			log.Fatalf("%v\n", err)
+		}
 
-		// Create a connection pool
-		connString := root.GetDBConnString()
-		config, err := pgxpool.ParseConfig(connString)
-		if err != nil {
-			log.Fatalf("Unable to parse connection string: %v\n", err)
+		// Merge the results
+		if !skipMerge {
+			fmt.Println("Merging results...")
+			merge.Command.Run(cmd, args)
 		}
+	},
+}
 
-		pool, err := pgxpool.NewWithConfig(ctx, config)
-		if err != nil {
-			log.Fatalf("Unable to create connection pool: %v\n", err)
+// runTests runs every ID type, encoding and row count in a schema of its own, which is dropped when it returns
+func runTests(ctx context.Context) error {
+	// Get all ID types
+	idTypes := common.GetAllIDTypes()
+	rowCounts := common.GetDefaultRowCounts()
+
+	// Create a connection pool that works in a schema of its own
+	pool, schema, err := common.NewRunPool(ctx, root.GetDBConnString())
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+	defer func() {
+		if err := common.DropRunSchema(ctx, pool, schema); err != nil {
+			log.Printf("Error dropping schema %s: %v", schema, err)
 		}
-		defer pool.Close()
+	}()
 
-		// Run tests for all ID types and row counts
-		for _, idType := range idTypes {
+	// Run tests for all ID types, encodings and row counts
+	for _, idType := range idTypes {
+		for _, encoding := range encodings {
 			// Get the ID generator
-			generator, err := common.GetIDGenerator(idType)
+			generator, err := common.GetEncodedIDGenerator(idType, encoding)
+			if errors.Is(err, ids.ErrUnsupportedEncoding) {
+				fmt.Printf("Skipping %s with %s encoding: %v\n", idType, encoding, err)
+				continue
+			}
 			if err != nil {
 				log.Printf("Error getting ID generator for %s: %v", idType, err)
 				continue
@@ -55,17 +77,24 @@ This is equivalent to running the id command for each ID type and then the merge
 				// Run the test
 				fmt.Printf("Running test for %s with %d rows...\n", generator.Name(), count)
 				duration, stats, err := common.RunTest(ctx, pool, generator, count)
+				if errors.Is(err, ids.ErrUnavailable) {
+					// The server doesn't support this generator, so skip the remaining row counts
+					fmt.Printf("Skipping %s: %v\n", generator.Name(), err)
+					break
+				}
 				if err != nil {
 					log.Printf("Error running test for %s with %d rows: %v", generator.Name(), count, err)
 					continue
 				}
 
 				// Save the result
+				metadata := generator.Metadata()
 				result := common.TestResult{
 					IDType:   generator.Name(),
 					Count:    count,
 					Duration: duration,
 					Stats:    stats,
+					Metadata: &metadata,
 				}
 
 				if err := common.SaveTestResult(result); err != nil {
@@ -82,13 +111,9 @@ This is equivalent to running the id command for each ID type and then the merge
 				log.Printf("Error dropping table for %s: %v", generator.Name(), err)
 			}
 		}
+	}
 
-		// Merge the results
-		if !skipMerge {
-			fmt.Println("Merging results...")
-			merge.Command.Run(cmd, args)
-		}
-	},
+	return nil
 }
 
 func init() {
@@ -97,4 +122,5 @@ func init() {
 
 	// Define flags
 	Command.Flags().BoolVar(&skipMerge, "skip-merge", false, "Skip merging the results")
+	Command.Flags().StringSliceVar(&encodings, "encodings", []string{common.NativeEncoding}, "Column types to store the IDs in (native, text, varchar, char, bytea, uuid)")
 }
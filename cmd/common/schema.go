@@ -0,0 +1,54 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RunSchemaPrefix prefixes the names of the schemas created for each run
+const RunSchemaPrefix = "compareids_run_"
+
+// RunPoolMinConns is the smallest pool a run uses. RunTest holds a connection for its transaction,
+// and generators that insert concurrently, e.g. identity:workers=8, need one connection per worker on top of it.
+const RunPoolMinConns = 16
+
+// NewRunPool connects to the database and creates a uniquely named schema for this run. Every connection in the
+// pool puts the schema first on its search_path, so the tables, sequences and functions created by the generators
+// never collide with those of another run. public stays on the path for extensions.
+// Call DropRunSchema with the returned schema when the run is done.
+func NewRunPool(ctx context.Context, connString string) (*pgxpool.Pool, string, error) {
+	config, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to parse connection string: %w", err)
+	}
+
+	schema := fmt.Sprintf("%s%s_%04x", RunSchemaPrefix, time.Now().UTC().Format("20060102150405"), rand.N(1<<16))
+	config.ConnConfig.RuntimeParams["search_path"] = pgx.Identifier{schema}.Sanitize() + ", public"
+
+	// The default pool size depends on the number of CPUs, which would cap concurrent inserts on small machines
+	config.MaxConns = max(config.MaxConns, RunPoolMinConns)
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to create connection pool: %w", err)
+	}
+
+	_, err = pool.Exec(ctx, "CREATE SCHEMA "+pgx.Identifier{schema}.Sanitize())
+	if err != nil {
+		pool.Close()
+		return nil, "", fmt.Errorf("unable to create schema %s: %w", schema, err)
+	}
+
+	return pool, schema, nil
+}
+
+// DropRunSchema drops a schema created by NewRunPool together with everything in it
+func DropRunSchema(ctx context.Context, pool *pgxpool.Pool, schema string) error {
+	_, err := pool.Exec(ctx, "DROP SCHEMA IF EXISTS "+pgx.Identifier{schema}.Sanitize()+" CASCADE")
+	return err
+}
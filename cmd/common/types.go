@@ -1,11 +1,16 @@
 package common
 
+import "github.com/jirevwe/compareids/ids"
+
 // TestResult holds the result of a test
 type TestResult struct {
 	IDType   string
 	Count    uint64
 	Duration float64
 	Stats    map[string]string
+
+	// Metadata describes the generator, it is missing from results saved before it was added
+	Metadata *ids.Metadata `json:",omitempty"`
 }
 
 // TemplateData represents the structure of the data.json file
@@ -13,6 +18,7 @@ type TemplateData struct {
 	Data      map[string][]map[string]interface{} `json:"Data"`
 	IDTypes   []string                            `json:"IDTypes"`
 	RowCounts []uint64                            `json:"RowCounts"`
+	Metadata  map[string]ids.Metadata             `json:"Metadata"`
 }
 
 // ResultsDir is the directory where individual test results are stored
@@ -20,3 +26,6 @@ const ResultsDir = "results"
 
 // DataFile is the path to the template data file
 const DataFile = "data.json"
+
+// NativeEncoding stores IDs in the column type chosen by their generator
+const NativeEncoding = "native"
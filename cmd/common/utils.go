@@ -13,66 +13,45 @@ import (
 	"github.com/jirevwe/compareids/ids"
 )
 
-// GetIDGenerator returns the ID generator for the given ID type
+// GetIDGenerator returns the ID generator for the given ID type from the ids registry.
+// Configurable generators take their options after the key, e.g. "cuid2:length=10".
 func GetIDGenerator(idType string) (ids.IDGenerator, error) {
-	switch idType {
-	case "bigserial":
-		return ids.NewBigSerialGenerator(), nil
-	case "snowflake":
-		return ids.NewSnowflakeGenerator(), nil
-	case "uuidv4":
-		return ids.NewUUIDv4Generator(), nil
-	case "uuidv4-db":
-		return ids.NewUUIDv4DBGenerator(), nil
-	case "uuidv7":
-		return ids.NewUUIDv7Generator(), nil
-	case "uuidv7-db":
-		return ids.NewUUIDv7DBGenerator(), nil
-	case "uuidv7-google":
-		return ids.NewUUIDv7GoogleGenerator(), nil
-	case "ulid":
-		return ids.NewULIDGenerator(), nil
-	case "ulid-db":
-		return ids.NewULIDDBGenerator(), nil
-	case "ulid-pg":
-		return ids.NewULIDPGGenerator(), nil
-	case "xid":
-		return ids.NewXIDGenerator(), nil
-	case "cuid":
-		return ids.NewCUIDGenerator(), nil
-	case "ksuid":
-		return ids.NewKSUIDGenerator(), nil
-	case "nanoid":
-		return ids.NewNanoIDGenerator(), nil
-	case "typeid":
-		return ids.NewTypeIDGenerator(), nil
-	case "mongoid":
-		return ids.NewMongoIDGenerator(), nil
-	default:
-		return nil, fmt.Errorf("unknown ID type: %s", idType)
+	return ids.New(idType)
+}
+
+// GetEncodedIDGenerator returns the ID generator for the given ID type, storing its IDs using the given encoding.
+// The native encoding keeps the generator's own column type.
+func GetEncodedIDGenerator(idType string, encoding string) (ids.IDGenerator, error) {
+	generator, err := GetIDGenerator(idType)
+	if err != nil {
+		return nil, err
 	}
+
+	if encoding == "" || encoding == NativeEncoding {
+		return generator, nil
+	}
+
+	enc, err := ids.ParseEncoding(encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generators without the capability are either generated by the database or are themselves a column type
+	// variant of another ID type, e.g. uuidv4-text
+	key, _, err := ids.ParseIDType(idType)
+	if err != nil {
+		return nil, err
+	}
+	if r, _ := ids.Lookup(key); !r.Capabilities.Has(ids.CapabilityEncodings) {
+		return nil, fmt.Errorf("%w: %s can only be stored in its native column type", ids.ErrUnsupportedEncoding, idType)
+	}
+
+	return ids.NewEncodedGenerator(idType, generator, enc)
 }
 
-// GetAllIDTypes returns a list of all supported ID types
+// GetAllIDTypes returns every registered ID type, followed by its presets
 func GetAllIDTypes() []string {
-	return []string{
-		"bigserial",
-		"snowflake",
-		"uuidv4",
-		"uuidv4-db",
-		"uuidv7",
-		"uuidv7-db",
-		"uuidv7-google",
-		"ulid",
-		"ulid-db",
-		"ulid-pg",
-		"xid",
-		"cuid",
-		"ksuid",
-		"nanoid",
-		"typeid",
-		"mongoid",
-	}
+	return ids.IDTypes()
 }
 
 // GetDefaultRowCounts returns the default row counts to test
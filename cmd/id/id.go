@@ -1,18 +1,22 @@
 package id
 
 import (
+	"errors"
 	"fmt"
 	"log"
 
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jirevwe/compareids/cmd/common"
 	"github.com/jirevwe/compareids/cmd/root"
+	"github.com/jirevwe/compareids/ids"
 	"github.com/spf13/cobra"
 )
 
 var (
 	// rowCount is the number of rows to generate
 	rowCount uint64
+
+	// encoding is the column type used to store the IDs
+	encoding string
 )
 
 // Command represents the id command
@@ -20,37 +24,45 @@ var Command = &cobra.Command{
 	Use:   "id [id-type]",
 	Short: "Generate test data for a specific ID type",
 	Long: `Generate test data for a specific ID type and save the results to a JSON file.
-Example: compareids id uuidv4 --count 10000`,
+Example: compareids id uuidv4 --count 10000
+Example: compareids id ulid --encoding bytea`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	// Errors are returned rather than fatal, so that the deferred cleanup of the run's schema always runs.
+	// Execute prints them.
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 
 		idType := args[0]
 
 		// Get the ID generator
-		generator, err := common.GetIDGenerator(idType)
+		generator, err := common.GetEncodedIDGenerator(idType, encoding)
 		if err != nil {
-			log.Fatalf("Error getting ID generator: %v", err)
+			return fmt.Errorf("error getting ID generator: %w", err)
 		}
 
-		// Create a connection pool
-		connString := root.GetDBConnString()
-		config, err := pgxpool.ParseConfig(connString)
+		// Create a connection pool that works in a schema of its own
+		pool, schema, err := common.NewRunPool(ctx, root.GetDBConnString())
 		if err != nil {
-			log.Fatalf("Unable to parse connection string: %v\n", err)
-		}
-
-		pool, err := pgxpool.NewWithConfig(ctx, config)
-		if err != nil {
-			log.Fatalf("Unable to create connection pool: %v\n", err)
+			return err
 		}
 		defer pool.Close()
+		defer func() {
+			if err := common.DropRunSchema(ctx, pool, schema); err != nil {
+				log.Printf("Error dropping schema %s: %v", schema, err)
+			}
+		}()
 
 		// Run the test
 		fmt.Printf("Running test for %s with %d rows...\n", generator.Name(), rowCount)
 		duration, stats, err := common.RunTest(ctx, pool, generator, rowCount)
+		if errors.Is(err, ids.ErrUnavailable) {
+			fmt.Printf("Skipping %s: %v\n", generator.Name(), err)
+			return nil
+		}
 		if err != nil {
-			log.Fatalf("Error running test: %v", err)
+			return fmt.Errorf("error running test: %w", err)
 		}
 
 		// Drop the table
@@ -59,19 +71,22 @@ Example: compareids id uuidv4 --count 10000`,
 		}
 
 		// Save the result
+		metadata := generator.Metadata()
 		result := common.TestResult{
 			IDType:   generator.Name(),
 			Count:    rowCount,
 			Duration: duration,
 			Stats:    stats,
+			Metadata: &metadata,
 		}
 
 		if err := common.SaveTestResult(result); err != nil {
-			log.Fatalf("Error saving test result: %v", err)
+			return fmt.Errorf("error saving test result: %w", err)
 		}
 
 		fmt.Printf("Test completed in %.2fms. Results saved to %s/%s_%d.json\n",
 			duration, common.ResultsDir, generator.Name(), rowCount)
+		return nil
 	},
 }
 
@@ -81,6 +96,7 @@ func init() {
 
 	// Define flags
 	Command.Flags().Uint64Var(&rowCount, "count", 10000, "Number of rows to generate")
+	Command.Flags().StringVar(&encoding, "encoding", common.NativeEncoding, "Column type to store the IDs in (native, text, varchar, char, bytea, uuid)")
 }
 
 // GetSupportedIDTypes returns a list of supported ID types
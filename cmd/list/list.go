@@ -1,31 +1,102 @@
 package list
 
 import (
+	"encoding/json"
 	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
 
 	"github.com/jirevwe/compareids/cmd/common"
 	"github.com/jirevwe/compareids/cmd/root"
+	"github.com/jirevwe/compareids/ids"
 	"github.com/spf13/cobra"
 )
 
+var (
+	// details prints the metadata of every ID type instead of the registered keys
+	details bool
+
+	// asJSON prints the details as JSON
+	asJSON bool
+)
+
+// generatorDetails is an ID type with the metadata of its generator
+type generatorDetails struct {
+	IDType string `json:"id_type"`
+	Name   string `json:"name"`
+	ids.Metadata
+}
+
 // Command represents the list command
 var Command = &cobra.Command{
 	Use:   "list",
 	Short: "List all available ID types",
-	Long:  `List all available ID types that can be used with the id command.`,
+	Long: `List all available ID types that can be used with the id command.
+Use --details to describe each ID type and its presets, or --json for the same details as JSON.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		// Get all ID types
-		idTypes := common.GetAllIDTypes()
+		if details || asJSON {
+			printDetails()
+			return
+		}
 
-		// Print the ID types
+		// Print the registered ID types, with the presets run by the all command under each one
 		fmt.Println("Available ID types:")
-		for _, idType := range idTypes {
-			fmt.Printf("  - %s\n", idType)
+		for _, r := range ids.Registrations() {
+			fmt.Printf("  - %-18s %s (%s)\n", r.Key, r.Description, r.Capabilities)
+			for _, preset := range r.Presets {
+				fmt.Printf("      %s:%s\n", r.Key, preset)
+			}
 		}
 	},
 }
 
+func printDetails() {
+	var all []generatorDetails
+	for _, idType := range common.GetAllIDTypes() {
+		generator, err := common.GetIDGenerator(idType)
+		if err != nil {
+			log.Fatalf("Error getting ID generator for %s: %v", idType, err)
+		}
+		all = append(all, generatorDetails{IDType: idType, Name: generator.Name(), Metadata: generator.Metadata()})
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(all); err != nil {
+			log.Fatalf("Error encoding details: %v", err)
+		}
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID TYPE\tBITS\tRANDOM\tTIMESTAMP\tK-SORTABLE\tSOURCE\tLENGTH\tALPHABET\tSTORAGE")
+	for _, d := range all {
+		timestamp := "-"
+		if d.TimestampBits > 0 {
+			timestamp = fmt.Sprintf("%d (%s)", d.TimestampBits, d.TimestampResolution)
+		}
+
+		length := "varies"
+		if d.StringLength > 0 {
+			length = strconv.Itoa(d.StringLength)
+		} else if d.MaxStringLength > 0 {
+			length = "up to " + strconv.Itoa(d.MaxStringLength)
+		}
+
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%t\t%s\t%s\t%s\t%s\n", d.IDType, d.Bits,
+			strconv.FormatFloat(d.RandomBits, 'f', -1, 64), timestamp, d.KSortable, d.Source, length, d.Alphabet, d.Storage)
+	}
+	w.Flush()
+}
+
 func init() {
 	// Add the list command to the root command
 	root.RootCmd.AddCommand(Command)
+
+	// Define flags
+	Command.Flags().BoolVar(&details, "details", false, "Describe each ID type and its presets")
+	Command.Flags().BoolVar(&asJSON, "json", false, "Print the details as JSON")
 }
@@ -7,6 +7,9 @@ import (
 	_ "github.com/jirevwe/compareids/cmd/list"
 	_ "github.com/jirevwe/compareids/cmd/merge"
 	"github.com/jirevwe/compareids/cmd/root"
+
+	// Register the generators defined in the sql/ directory
+	_ "github.com/jirevwe/compareids/sql"
 )
 
 func main() {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/jirevwe/compareids/cmd/common"
 	"github.com/jirevwe/compareids/cmd/root"
+	"github.com/jirevwe/compareids/ids"
 	"github.com/spf13/cobra"
 )
 
@@ -63,6 +64,14 @@ This command should be run after generating test data for all ID types.`,
 			Data:      make(map[string][]map[string]interface{}),
 			IDTypes:   idTypes,
 			RowCounts: rowCounts,
+			Metadata:  make(map[string]ids.Metadata),
+		}
+
+		// Describe each ID type, so the report can show its properties next to its results
+		for _, result := range results {
+			if result.Metadata != nil {
+				templateData.Metadata[result.IDType] = *result.Metadata
+			}
 		}
 
 		// Process the results for the template
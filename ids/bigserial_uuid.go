@@ -0,0 +1,88 @@
+package ids
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BigSerialUUIDGenerator generates a BigSerial primary key with a UUIDv4 secondary key
+type BigSerialUUIDGenerator struct {
+	*Table
+}
+
+var _ IDGenerator = (*BigSerialUUIDGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "bigserial-uuid",
+		Description:  "BIGSERIAL primary key with a unique random UUID column",
+		Capabilities: CapabilityDatabase,
+		New:          withoutOptions(NewBigSerialUUIDGenerator),
+	})
+}
+
+func NewBigSerialUUIDGenerator(table string) *BigSerialUUIDGenerator {
+	g := &BigSerialUUIDGenerator{}
+	g.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "BIGSERIAL",
+		Columns:  []string{"u UUID NOT NULL DEFAULT gen_random_uuid() UNIQUE"},
+		Stats:    g.uuidIndexStats,
+	})
+	return g
+}
+
+func (g *BigSerialUUIDGenerator) Generate() string {
+	// Both keys are generated by the database
+	return ""
+}
+
+func (g *BigSerialUUIDGenerator) Name() string {
+	return "BIGSERIAL + UUIDv4 - BIGINT, UUID"
+}
+
+func (g *BigSerialUUIDGenerator) Metadata() Metadata {
+	// The primary key is described, the UUID column adds 122 random bits next to it
+	return Metadata{
+		Bits:      64,
+		KSortable: true,
+		Source:    SourceDatabase,
+		Alphabet:  AlphabetDecimal,
+		Storage:   "BIGINT, UUID",
+	}
+}
+
+// uuidIndexStats adds the stats for the unique index on the UUID column
+func (g *BigSerialUUIDGenerator) uuidIndexStats(ctx context.Context, pool *pgxpool.Pool, stats map[string]any) error {
+	// The index is named by PostgreSQL after the table, so look it up
+	var index string
+	err := pool.QueryRow(ctx, "SELECT indexrelid::regclass::text FROM pg_index WHERE indrelid = $1::regclass AND NOT indisprimary",
+		g.TableName()).Scan(&index)
+	if err != nil {
+		return err
+	}
+
+	var uuidIndexStats IndexStats
+
+	err = pool.QueryRow(ctx, fmt.Sprintf(fmtIndexStatsQuery, index)).Scan(
+		&uuidIndexStats.IndexSize,
+		&uuidIndexStats.InternalPages,
+		&uuidIndexStats.LeafPages,
+		&uuidIndexStats.Density,
+		&uuidIndexStats.Fragmentation,
+	)
+	if err != nil {
+		return err
+	}
+
+	stats["uuid_index_size"] = uuidIndexStats.IndexSize
+	stats["uuid_index_internal_pages"] = uuidIndexStats.InternalPages
+	stats["uuid_index_leaf_pages"] = uuidIndexStats.LeafPages
+	stats["uuid_index_density"] = uuidIndexStats.Density
+	stats["uuid_index_fragmentation"] = uuidIndexStats.Fragmentation
+	stats["uuid_index_internal_to_leaf_ratio"] = float64(uuidIndexStats.InternalPages) / float64(uuidIndexStats.LeafPages)
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package ids
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	DefaultCuid2Length = 24
+	MinCuid2Length     = 2
+	MaxCuid2Length     = 32
+
+	cuid2BigLength       = 32
+	cuid2InitialCountMax = 476782367
+)
+
+// Cuid2Generator generates Cuid2 IDs, the successor to CUID.
+// Each ID is a random letter followed by a SHA3-512 hash of the time, random entropy, a counter and a
+// per-process fingerprint, encoded in base 36 and truncated to the configured length.
+// Reference: https://github.com/paralleldrive/cuid2
+type Cuid2Generator struct {
+	*Table
+
+	length      int
+	counter     atomic.Int64
+	fingerprint string
+}
+
+var _ IDGenerator = (*Cuid2Generator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "cuid2",
+		Description:  "Cuid2 hashed ID with a configurable length",
+		Capabilities: CapabilityClient | CapabilityEncodings | CapabilityOptions,
+		New:          withOptions(NewCuid2Generator),
+		Presets:      []string{"length=10"},
+	})
+}
+
+// NewCuid2Generator returns a Cuid2 generator. Supported options: length (2 to 32, default 24).
+func NewCuid2Generator(table string, opts Options) (*Cuid2Generator, error) {
+	if err := opts.Validate("length"); err != nil {
+		return nil, err
+	}
+
+	length, err := opts.Int("length", DefaultCuid2Length)
+	if err != nil {
+		return nil, err
+	}
+
+	if length < MinCuid2Length || length > MaxCuid2Length {
+		return nil, fmt.Errorf("cuid2 length must be between %d and %d, got %d", MinCuid2Length, MaxCuid2Length, length)
+	}
+
+	hostname, _ := os.Hostname()
+
+	c := &Cuid2Generator{length: length}
+	c.counter.Store(randomBelow(cuid2InitialCountMax))
+	c.fingerprint = cuid2Hash(hostname + strconv.Itoa(os.Getpid()) + cuid2Entropy(cuid2BigLength))[:cuid2BigLength]
+	c.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: fmt.Sprintf("VARCHAR(%d)", length),
+		Value:    func(uint64) any { return c.Generate() },
+	})
+	return c, nil
+}
+
+func (c *Cuid2Generator) Generate() string {
+	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 36)
+	count := strconv.FormatInt(c.counter.Add(1), 36)
+	salt := cuid2Entropy(c.length)
+
+	firstLetter := string(rune('a' + randomBelow(26)))
+	return firstLetter + cuid2Hash(timestamp + salt + count + c.fingerprint)[1:c.length]
+}
+
+func (c *Cuid2Generator) Name() string {
+	// The length is part of the name as well as the column type, so that text encodings of each length don't share a name
+	if c.length == DefaultCuid2Length {
+		return fmt.Sprintf("Cuid2 - VARCHAR(%d)", c.length)
+	}
+	return fmt.Sprintf("Cuid2 (%d chars) - VARCHAR(%d)", c.length, c.length)
+}
+
+func (c *Cuid2Generator) Metadata() Metadata {
+	// A random letter followed by a hash, which hides the time and counter that go into it
+	random := randomCharBits(1, 26) + randomCharBits(c.length-1, 36)
+	return Metadata{
+		Bits:         int(math.Ceil(random)),
+		RandomBits:   random,
+		Source:       SourceClient,
+		StringLength: c.length,
+		Alphabet:     AlphabetBase36,
+		Storage:      fmt.Sprintf("VARCHAR(%d)", c.length),
+	}
+}
+
+// cuid2Hash returns the SHA3-512 hash of input in base 36, without its first digit which is biased
+func cuid2Hash(input string) string {
+	sum := sha3.Sum512([]byte(input))
+	return new(big.Int).SetBytes(sum[:]).Text(36)[1:]
+}
+
+// cuid2Entropy returns length random base 36 digits
+func cuid2Entropy(length int) string {
+	var sb strings.Builder
+	for sb.Len() < length {
+		sb.WriteString(strconv.FormatInt(randomBelow(36), 36))
+	}
+	return sb.String()
+}
+
+// randomBelow returns a cryptographically random number in [0, n)
+func randomBelow(n int64) int64 {
+	v, err := rand.Int(rand.Reader, big.NewInt(n))
+	if err != nil {
+		panic(err)
+	}
+	return v.Int64()
+}
@@ -0,0 +1,187 @@
+package ids
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Encoding is the column type used to store an ID
+type Encoding string
+
+const (
+	EncodingText    Encoding = "text"
+	EncodingVarchar Encoding = "varchar"
+	EncodingChar    Encoding = "char"
+	EncodingBytea   Encoding = "bytea"
+	EncodingUUID    Encoding = "uuid"
+)
+
+// ErrUnsupportedEncoding is returned when a generator can't be stored in the requested encoding
+var ErrUnsupportedEncoding = errors.New("unsupported storage encoding")
+
+// Encodings returns all supported storage encodings
+func Encodings() []Encoding {
+	return []Encoding{
+		EncodingText,
+		EncodingVarchar,
+		EncodingChar,
+		EncodingBytea,
+		EncodingUUID,
+	}
+}
+
+// ParseEncoding returns the encoding with the given name
+func ParseEncoding(name string) (Encoding, error) {
+	for _, encoding := range Encodings() {
+		if string(encoding) == strings.ToLower(name) {
+			return encoding, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedEncoding, name)
+}
+
+// BinaryGenerator is implemented by client-side generators that can return the raw bytes of an ID,
+// so the same ID can be stored as text, BYTEA or a native UUID
+type BinaryGenerator interface {
+	IDGenerator
+	GenerateBytes() []byte
+}
+
+// encodingChecker is implemented by generators that can only be stored in some encodings
+type encodingChecker interface {
+	checkEncoding(encoding Encoding) error
+}
+
+// specStatser is implemented by generators that embed *Table
+type specStatser interface {
+	specStats(ctx context.Context, pool *pgxpool.Pool, stats map[string]any) error
+}
+
+// EncodedGenerator stores the IDs of a client-side generator in the given encoding.
+// Any generator can be stored in a text column, BYTEA and UUID columns need a BinaryGenerator.
+type EncodedGenerator struct {
+	*Table
+
+	generator IDGenerator
+	encoding  Encoding
+	column    string
+}
+
+var _ IDGenerator = (*EncodedGenerator)(nil)
+
+// NewEncodedGenerator wraps g so that its IDs are stored using encoding. The key is used to name the table.
+func NewEncodedGenerator(key string, g IDGenerator, encoding Encoding) (*EncodedGenerator, error) {
+	if c, ok := g.(encodingChecker); ok {
+		if err := c.checkEncoding(encoding); err != nil {
+			return nil, err
+		}
+	}
+
+	binary, isBinary := g.(BinaryGenerator)
+	if (encoding == EncodingBytea || encoding == EncodingUUID) && !isBinary {
+		return nil, fmt.Errorf("%w: %s can't be stored as %s", ErrUnsupportedEncoding, g.Name(), encoding)
+	}
+
+	// VARCHAR and CHAR columns are sized for the longest string form, so that every ID fits and the name is stable
+	length := g.Metadata().StringLength
+	if length == 0 {
+		length = g.Metadata().MaxStringLength
+	}
+	if (encoding == EncodingVarchar || encoding == EncodingChar) && length == 0 {
+		return nil, fmt.Errorf("%w: %s has no maximum string length to size a %s column", ErrUnsupportedEncoding, g.Name(), encoding)
+	}
+
+	var column string
+	switch encoding {
+	case EncodingText:
+		column = "TEXT"
+	case EncodingVarchar:
+		column = fmt.Sprintf("VARCHAR(%d)", length)
+	case EncodingChar:
+		column = fmt.Sprintf("CHAR(%d)", length)
+	case EncodingBytea:
+		column = "BYTEA"
+	case EncodingUUID:
+		if size := len(binary.GenerateBytes()); size != 16 {
+			return nil, fmt.Errorf("%w: %s has %d bits, a UUID column needs 128", ErrUnsupportedEncoding, g.Name(), size*8)
+		}
+		column = "UUID"
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncoding, encoding)
+	}
+
+	// Storing the IDs in the generator's own column type would only repeat the native run under another name
+	if strings.EqualFold(column, g.Metadata().Storage) {
+		return nil, fmt.Errorf("%w: %s is already stored as %s", ErrUnsupportedEncoding, g.Name(), column)
+	}
+
+	e := &EncodedGenerator{generator: g, encoding: encoding, column: column}
+	e.Table = NewTable(TableSpec{
+		Name:     TableNameFor(key + ":" + string(encoding)),
+		IDColumn: column,
+		Value:    func(uint64) any { return e.value() },
+		Stats: func(ctx context.Context, pool *pgxpool.Pool, stats map[string]any) error {
+			// Keep the wrapped generator's stats, so results can still be traced back to its options
+			if s, ok := e.generator.(specStatser); ok {
+				if err := s.specStats(ctx, pool, stats); err != nil {
+					return err
+				}
+			}
+
+			// Record the encoding so results can be grouped by column type
+			stats["encoding"] = string(e.encoding)
+			return nil
+		},
+	})
+	return e, nil
+}
+
+// tableKey turns an ID type such as "snowflake:nodes=8" into an identifier that is safe to use in a table name
+func tableKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(key))
+}
+
+// Generate returns a new ID in the form it is stored in
+func (e *EncodedGenerator) Generate() string {
+	switch e.encoding {
+	case EncodingBytea:
+		return fmt.Sprintf("\\x%x", e.generator.(BinaryGenerator).GenerateBytes())
+	default:
+		return e.generator.Generate()
+	}
+}
+
+// Name returns the generator's name with its column type replaced by the encoding's
+func (e *EncodedGenerator) Name() string {
+	// Generator names are formatted as "<ID type> - <column type>"
+	name, _, _ := strings.Cut(e.generator.Name(), " - ")
+	return fmt.Sprintf("%s - %s", name, e.column)
+}
+
+// Metadata returns the generator's metadata with the storage replaced by the encoding's column type
+func (e *EncodedGenerator) Metadata() Metadata {
+	m := e.generator.Metadata()
+	m.Storage = e.column
+	return m
+}
+
+func (e *EncodedGenerator) value() any {
+	switch e.encoding {
+	case EncodingBytea:
+		return e.generator.(BinaryGenerator).GenerateBytes()
+	case EncodingUUID:
+		// pgx encodes a [16]byte as a native UUID
+		return [16]byte(e.generator.(BinaryGenerator).GenerateBytes())
+	default:
+		return e.generator.Generate()
+	}
+}
@@ -0,0 +1,118 @@
+package ids
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodedTextOnlyGenerator(t *testing.T) {
+	g, err := New("nanoid")
+	require.NoError(t, err)
+
+	e, err := NewEncodedGenerator("nanoid", g, EncodingText)
+	require.NoError(t, err)
+	assert.Equal(t, "NanoID - TEXT", e.Name())
+	assert.Len(t, e.Generate(), DefaultNanoIDSize)
+
+	_, err = NewEncodedGenerator("nanoid", g, EncodingBytea)
+	assert.ErrorIs(t, err, ErrUnsupportedEncoding)
+}
+
+func TestEncodedTypeIDUUIDStorage(t *testing.T) {
+	g, err := New("typeid:prefix=user,storage=uuid")
+	require.NoError(t, err)
+
+	for _, encoding := range Encodings() {
+		_, err = NewEncodedGenerator("typeid:prefix=user,storage=uuid", g, encoding)
+		assert.ErrorIs(t, err, ErrUnsupportedEncoding, encoding)
+	}
+}
+
+func TestEncodedVarcharIsSizedForTheLongestID(t *testing.T) {
+	g, err := New("random-bigint")
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		e, err := NewEncodedGenerator("random-bigint", g, EncodingVarchar)
+		require.NoError(t, err)
+		assert.Equal(t, "Random - VARCHAR(20)", e.Name())
+	}
+}
+
+func TestEncodedKeepsGeneratorStats(t *testing.T) {
+	for _, idType := range []string{"uuidv7:method=random", "ulid:entropy=monotonic", "snowflake:nodes=32", "nanoid:size=12", "typeid:prefix=user", "uuidv8"} {
+		g, err := New(idType)
+		require.NoError(t, err)
+
+		native := make(map[string]any)
+		require.NoError(t, g.(specStatser).specStats(context.Background(), nil, native))
+		require.NotEmpty(t, native, idType)
+
+		// ULIDs are natively stored as text, so they are checked as VARCHAR
+		encoding := EncodingText
+		if g.Metadata().Storage == "TEXT" {
+			encoding = EncodingVarchar
+		}
+
+		e, err := NewEncodedGenerator(idType, g, encoding)
+		require.NoError(t, err, idType)
+
+		encoded := make(map[string]any)
+		require.NoError(t, e.specStats(context.Background(), nil, encoded))
+
+		for name, value := range native {
+			assert.Equal(t, value, encoded[name], "%s: %s", idType, name)
+		}
+		assert.Equal(t, string(encoding), encoded["encoding"], idType)
+	}
+}
+
+func TestEncodedNativeColumnIsRejected(t *testing.T) {
+	for _, tc := range []struct {
+		idType   string
+		encoding Encoding
+	}{
+		{"ksuid", EncodingVarchar},
+		{"ulid", EncodingText},
+		{"uuidv4", EncodingUUID},
+		// The bytes of a Crockford TSID are those of tsid --encoding bytea
+		{"tsid-text", EncodingBytea},
+	} {
+		g, err := New(tc.idType)
+		require.NoError(t, err)
+
+		_, err = NewEncodedGenerator(tc.idType, g, tc.encoding)
+		assert.ErrorIs(t, err, ErrUnsupportedEncoding, tc.idType)
+	}
+}
+
+// Results are saved under the generator's name, so two runs with the same name overwrite each other
+func TestEncodedNamesAreUnique(t *testing.T) {
+	names := make(map[string]string)
+	for _, idType := range IDTypes() {
+		g, err := New(idType)
+		require.NoError(t, err)
+
+		generators := map[string]IDGenerator{idType: g}
+
+		key, _, err := ParseIDType(idType)
+		require.NoError(t, err)
+		if r, _ := Lookup(key); r.Capabilities.Has(CapabilityEncodings) {
+			for _, encoding := range Encodings() {
+				e, err := NewEncodedGenerator(idType, g, encoding)
+				if err == nil {
+					generators[idType+" --encoding "+string(encoding)] = e
+				}
+			}
+		}
+
+		for run, generator := range generators {
+			other, ok := names[generator.Name()]
+			assert.False(t, ok, "%s and %s are both named %s", run, other, generator.Name())
+			names[generator.Name()] = run
+		}
+	}
+}
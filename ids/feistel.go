@@ -0,0 +1,195 @@
+package ids
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// DefaultFeistelKey is the key used to derive the round keys unless another one is given
+	DefaultFeistelKey = 0x5eed
+
+	feistelHalfBits   = 31
+	feistelHalfMask   = 1<<feistelHalfBits - 1
+	feistelMultiplier = 0x5bd1e995
+	feistelRounds     = 4
+)
+
+// feistelCipher is a keyed permutation of the 62-bit integers, so sequential values map to random-looking
+// positive BIGINTs without collisions. All intermediate values fit in a signed BIGINT, so the same rounds can run in
+// PL/pgSQL, see feistelFunctionSQL.
+type feistelCipher struct {
+	keys [feistelRounds]int64
+}
+
+func newFeistelCipher(key int64) *feistelCipher {
+	var c feistelCipher
+
+	// Derive the round keys with splitmix64
+	state := uint64(key)
+	for i := range c.keys {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		c.keys[i] = int64((z ^ (z >> 31)) & feistelHalfMask)
+	}
+
+	return &c
+}
+
+func feistelRound(right, key int64) int64 {
+	product := (right ^ key) * feistelMultiplier
+	return (product ^ (product >> feistelHalfBits)) & feistelHalfMask
+}
+
+// permute maps a value in [0, 2^62) to a unique value in the same range
+func (c *feistelCipher) permute(value int64) int64 {
+	left, right := value>>feistelHalfBits, value&feistelHalfMask
+	for _, key := range c.keys {
+		left, right = right, left^feistelRound(right, key)
+	}
+	return left<<feistelHalfBits | right
+}
+
+// unpermute reverses permute
+func (c *feistelCipher) unpermute(value int64) int64 {
+	left, right := value>>feistelHalfBits, value&feistelHalfMask
+	for i := len(c.keys) - 1; i >= 0; i-- {
+		left, right = right^feistelRound(left, c.keys[i]), left
+	}
+	return left<<feistelHalfBits | right
+}
+
+// feistelFunctionSQL returns a PL/pgSQL feistel_permute(bigint) function that applies the same rounds as permute
+func (c *feistelCipher) feistelFunctionSQL() string {
+	return fmt.Sprintf(`
+	CREATE OR REPLACE FUNCTION feistel_permute(p_value BIGINT) RETURNS BIGINT AS $$
+	DECLARE
+		c_keys BIGINT[] := ARRAY[%d, %d, %d, %d];
+		c_mask BIGINT := %d;
+		v_left BIGINT := p_value >> %d;
+		v_right BIGINT := p_value & c_mask;
+		v_product BIGINT;
+		v_tmp BIGINT;
+	BEGIN
+		FOR i IN 1..array_length(c_keys, 1) LOOP
+			v_product := (v_right # c_keys[i]) * %d;
+			v_tmp := v_right;
+			v_right := v_left # ((v_product # (v_product >> %d)) & c_mask);
+			v_left := v_tmp;
+		END LOOP;
+		RETURN (v_left << %d) | v_right;
+	END $$ LANGUAGE plpgsql IMMUTABLE STRICT;
+	`, c.keys[0], c.keys[1], c.keys[2], c.keys[3], feistelHalfMask, feistelHalfBits,
+		feistelMultiplier, feistelHalfBits, feistelHalfBits)
+}
+
+// FeistelGenerator generates IDs by drawing values from a database sequence and passing them through a keyed
+// Feistel cipher on the client, as a service sharing the sequence with other writers would
+type FeistelGenerator struct {
+	*Table
+
+	cipher *feistelCipher
+}
+
+var _ IDGenerator = (*FeistelGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "feistel",
+		Description:  "Database sequence permuted by a keyed Feistel cipher on the client",
+		Capabilities: CapabilityClient | CapabilityOptions,
+		New:          withOptions(NewFeistelGenerator),
+	})
+}
+
+// NewFeistelGenerator returns a Feistel generator. Supported options: key (default 0x5eed).
+func NewFeistelGenerator(table string, opts Options) (*FeistelGenerator, error) {
+	if err := opts.Validate("key"); err != nil {
+		return nil, err
+	}
+
+	key, err := opts.Int("key", DefaultFeistelKey)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &FeistelGenerator{cipher: newFeistelCipher(int64(key))}
+	f.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "BIGINT",
+		Setup: func(ctx context.Context, pool *pgxpool.Pool) error {
+			_, err := pool.Exec(ctx, "CREATE SEQUENCE IF NOT EXISTS feistel_seq")
+			return err
+		},
+	})
+	return f, nil
+}
+
+func (f *FeistelGenerator) Generate() string {
+	// The values come from the database sequence, see BulkWriteRecords
+	return ""
+}
+
+func (f *FeistelGenerator) Name() string {
+	return "Feistel Sequence - BIGINT"
+}
+
+func (f *FeistelGenerator) Metadata() Metadata {
+	// A permutation of the sequence, so IDs look random but none of their bits are
+	return Metadata{
+		Bits:     64,
+		Source:   SourceClient,
+		Alphabet: AlphabetDecimal,
+		Storage:  "BIGINT",
+	}
+}
+
+// nextValues draws count values from the sequence in a single query
+func (f *FeistelGenerator) nextValues(ctx context.Context, pool *pgxpool.Pool, count uint64) ([]int64, error) {
+	rows, err := pool.Query(ctx, "SELECT nextval('feistel_seq') FROM generate_series(1, $1)", count)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[int64])
+}
+
+func (f *FeistelGenerator) InsertRecord(ctx context.Context, pool *pgxpool.Pool) error {
+	values, err := f.nextValues(ctx, pool, 1)
+	if err != nil {
+		return err
+	}
+
+	_, err = pool.Exec(ctx, fmt.Sprintf("INSERT INTO %s (id, n) VALUES ($1, $2)", f.TableName()), f.cipher.permute(values[0]), 1)
+	return err
+}
+
+// BulkWriteRecords draws the sequence values, permutes them on the client and sends the inserts in a single batch
+func (f *FeistelGenerator) BulkWriteRecords(ctx context.Context, pool *pgxpool.Pool, count uint64) error {
+	values, err := f.nextValues(ctx, pool, count)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (id, n) VALUES ($1, $2)", f.TableName())
+	batch := &pgx.Batch{}
+	for i, value := range values {
+		batch.Queue(query, f.cipher.permute(value), i+1)
+	}
+	br := pool.SendBatch(ctx, batch)
+	return br.Close()
+}
+
+// DropTable drops the table and the sequence, so that the next run starts the sequence over
+func (f *FeistelGenerator) DropTable(ctx context.Context, pool *pgxpool.Pool) error {
+	if err := f.Table.DropTable(ctx, pool); err != nil {
+		return err
+	}
+
+	_, err := pool.Exec(ctx, "DROP SEQUENCE IF EXISTS feistel_seq")
+	return err
+}
@@ -0,0 +1,88 @@
+package ids
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// FeistelDBGenerator generates IDs by passing nextval() through a keyed Feistel cipher in the database
+type FeistelDBGenerator struct {
+	*Table
+
+	cipher *feistelCipher
+}
+
+var _ IDGenerator = (*FeistelDBGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "feistel-db",
+		Description:  "Database sequence permuted by a keyed Feistel cipher in PL/pgSQL",
+		Capabilities: CapabilityDatabase | CapabilityOptions,
+		New:          withOptions(NewFeistelDBGenerator),
+	})
+}
+
+// NewFeistelDBGenerator returns a database-side Feistel generator. Supported options: key (default 0x5eed).
+func NewFeistelDBGenerator(table string, opts Options) (*FeistelDBGenerator, error) {
+	if err := opts.Validate("key"); err != nil {
+		return nil, err
+	}
+
+	key, err := opts.Int("key", DefaultFeistelKey)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &FeistelDBGenerator{cipher: newFeistelCipher(int64(key))}
+	f.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "BIGINT DEFAULT feistel_permute(nextval('feistel_db_seq'))",
+		Setup: func(ctx context.Context, pool *pgxpool.Pool) error {
+			err := f.LoadFeistelFunction(ctx, pool)
+			if err != nil {
+				return err
+			}
+			_, err = pool.Exec(ctx, "CREATE SEQUENCE IF NOT EXISTS feistel_db_seq")
+			return err
+		},
+	})
+	return f, nil
+}
+
+func (f *FeistelDBGenerator) Generate() string {
+	// The ID is generated by the database
+	return ""
+}
+
+func (f *FeistelDBGenerator) Name() string {
+	return "Feistel Sequence (DB) - BIGINT"
+}
+
+func (f *FeistelDBGenerator) Metadata() Metadata {
+	return Metadata{
+		Bits:     64,
+		Source:   SourceDatabase,
+		Alphabet: AlphabetDecimal,
+		Storage:  "BIGINT",
+	}
+}
+
+func (f *FeistelDBGenerator) CreateTable(ctx context.Context, pool *pgxpool.Pool) error {
+	err := f.Table.CreateTable(ctx, pool)
+	if err != nil {
+		return err
+	}
+
+	// The sequence is owned by the table so that it is dropped with it
+	_, err = pool.Exec(ctx, fmt.Sprintf("ALTER SEQUENCE feistel_db_seq OWNED BY %s.id", f.TableName()))
+	return err
+}
+
+// LoadFeistelFunction creates the feistel_permute(bigint) function with this generator's round keys
+func (f *FeistelDBGenerator) LoadFeistelFunction(ctx context.Context, pool *pgxpool.Pool) error {
+	_, err := pool.Exec(ctx, f.cipher.feistelFunctionSQL())
+	return err
+}
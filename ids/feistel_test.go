@@ -0,0 +1,25 @@
+package ids
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeistelCipherIsAPermutation(t *testing.T) {
+	cipher := newFeistelCipher(DefaultFeistelKey)
+
+	seen := make(map[int64]bool)
+	for i := int64(1); i <= 100_000; i++ {
+		id := cipher.permute(i)
+		assert.GreaterOrEqual(t, id, int64(0))
+		assert.Less(t, id, int64(1)<<62)
+		assert.False(t, seen[id], "duplicate ID %d", id)
+		assert.Equal(t, i, cipher.unpermute(id))
+		seen[id] = true
+	}
+}
+
+func TestFeistelCipherKeysDiffer(t *testing.T) {
+	assert.NotEqual(t, newFeistelCipher(1).permute(42), newFeistelCipher(2).permute(42))
+}
@@ -0,0 +1,132 @@
+package ids
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/sync/errgroup"
+)
+
+// IdentityGenerator generates IDs with a GENERATED ALWAYS AS IDENTITY column.
+// The sequence's CACHE and INCREMENT BY are configurable, and rows can be inserted by concurrent workers,
+// since the cache only changes how values interleave when several sessions draw from the sequence.
+type IdentityGenerator struct {
+	*Table
+
+	cache     int
+	increment int
+	workers   int
+
+	// concurrency is the number of workers that inserted concurrently in the last bulk write,
+	// which is less than workers when the pool doesn't have a free connection for each of them
+	concurrency int
+}
+
+var _ IDGenerator = (*IdentityGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "identity",
+		Description:  "Identity column with a configurable sequence cache and increment",
+		Capabilities: CapabilityDatabase | CapabilityOptions,
+		New:          withOptions(NewIdentityGenerator),
+		Presets:      []string{"workers=8", "cache=50,workers=8"},
+	})
+}
+
+// NewIdentityGenerator returns an identity column generator.
+// Supported options: cache (default 1), increment (default 1) and workers (concurrent inserts, default 1).
+func NewIdentityGenerator(table string, opts Options) (*IdentityGenerator, error) {
+	if err := opts.Validate("cache", "increment", "workers"); err != nil {
+		return nil, err
+	}
+
+	cache, err := opts.Int("cache", 1)
+	if err != nil {
+		return nil, err
+	}
+
+	increment, err := opts.Int("increment", 1)
+	if err != nil {
+		return nil, err
+	}
+
+	workers, err := opts.Int("workers", 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if cache < 1 {
+		return nil, fmt.Errorf("identity cache must be at least 1, got %d", cache)
+	}
+
+	if increment == 0 {
+		return nil, fmt.Errorf("identity increment must not be 0")
+	}
+
+	if workers < 1 {
+		return nil, fmt.Errorf("identity workers must be at least 1, got %d", workers)
+	}
+
+	g := &IdentityGenerator{cache: cache, increment: increment, workers: workers}
+	g.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: fmt.Sprintf("BIGINT GENERATED ALWAYS AS IDENTITY (CACHE %d INCREMENT BY %d)", cache, increment),
+		Stats: func(_ context.Context, _ *pgxpool.Pool, stats map[string]any) error {
+			stats["sequence_cache"] = g.cache
+			stats["sequence_increment"] = g.increment
+			stats["workers"] = g.workers
+			stats["concurrency"] = g.concurrency
+			return nil
+		},
+	})
+	return g, nil
+}
+
+func (g *IdentityGenerator) Generate() string {
+	// Identity values are generated by the database
+	return ""
+}
+
+func (g *IdentityGenerator) Name() string {
+	params := []string{fmt.Sprintf("cache=%d", g.cache), fmt.Sprintf("increment=%d", g.increment)}
+	if g.workers > 1 {
+		params = append(params, fmt.Sprintf("workers=%d", g.workers))
+	}
+	return fmt.Sprintf("Identity (%s) - BIGINT", strings.Join(params, ", "))
+}
+
+func (g *IdentityGenerator) Metadata() Metadata {
+	return Metadata{
+		Bits:      64,
+		KSortable: true,
+		Source:    SourceDatabase,
+		Alphabet:  AlphabetDecimal,
+		Storage:   "BIGINT",
+	}
+}
+
+// BulkWriteRecords splits the rows between the workers, each of which inserts its share on its own connection.
+// Workers beyond the free connections of the pool would only wait for a connection, so they aren't started.
+func (g *IdentityGenerator) BulkWriteRecords(ctx context.Context, pool *pgxpool.Pool, count uint64) error {
+	free := int(pool.Config().MaxConns - pool.Stat().AcquiredConns())
+	g.concurrency = max(1, min(g.workers, free))
+
+	workers := uint64(g.concurrency)
+	chunk := (count + workers - 1) / workers
+
+	query := fmt.Sprintf("INSERT INTO %s (n) SELECT g.n FROM generate_series($1::bigint, $2::bigint) AS g(n)", g.TableName())
+
+	group, ctx := errgroup.WithContext(ctx)
+	for start := uint64(1); start <= count; start += chunk {
+		end := min(start+chunk-1, count)
+		group.Go(func() error {
+			_, err := pool.Exec(ctx, query, start, end)
+			return err
+		})
+	}
+
+	return group.Wait()
+}
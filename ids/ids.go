@@ -2,6 +2,7 @@ package ids
 
 import (
 	"context"
+	"errors"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -15,8 +16,12 @@ type IDGenerator interface {
 	BulkWriteRecords(ctx context.Context, pool *pgxpool.Pool, recordsWritten uint64) error
 	CollectStats(ctx context.Context, pool *pgxpool.Pool) (map[string]any, error)
 	Name() string
+	Metadata() Metadata
 }
 
+// ErrUnavailable is returned when a generator is not supported by the database server
+var ErrUnavailable = errors.New("id generator is not available on this server")
+
 // TableStats holds all the statistics for a table and its index
 type TableStats struct {
 	TotalTableSize string  `json:"total_table_size" db:"total_table_size"`
@@ -28,12 +33,20 @@ type TableStats struct {
 	Fragmentation  float64 `json:"index_fragmentation" db:"index_fragmentation"`
 }
 
-// LoadPGStatTuple ensures the pgstattuple extension is installed
+// LoadPGStatTuple ensures the pgstattuple extension is installed. It goes in public,
+// so that it outlives the schema of a run.
 func LoadPGStatTuple(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "CREATE EXTENSION IF NOT EXISTS pgstattuple")
+	_, err := pool.Exec(ctx, "CREATE EXTENSION IF NOT EXISTS pgstattuple WITH SCHEMA public")
 	return err
 }
 
+// ServerVersionNum returns the server version as reported by server_version_num, e.g. 180000
+func ServerVersionNum(ctx context.Context, pool *pgxpool.Pool) (int, error) {
+	var version int
+	err := pool.QueryRow(ctx, "SELECT current_setting('server_version_num')::int").Scan(&version)
+	return version, err
+}
+
 const fmtStatsQuery = `WITH table_stats AS (
 	SELECT
 		pg_total_relation_size('%s')::text AS total_table_size, 
@@ -49,8 +62,7 @@ index_stats AS (
 	FROM pgstatindex((
 		SELECT i.indexrelid::regclass::text
 		FROM pg_index i
-		JOIN pg_class c ON c.oid = i.indrelid
-		WHERE c.relname = '%s'
+		WHERE i.indrelid = '%s'::regclass
 		AND i.indisprimary
 		LIMIT 1
 	))
@@ -65,3 +77,20 @@ SELECT
 	COALESCE(leaf_fragmentation, 0.0) as index_fragmentation
 FROM table_stats t
 LEFT JOIN index_stats i ON true;`
+
+// IndexStats holds the statistics for a single index
+type IndexStats struct {
+	IndexSize     int64   `json:"index_size" db:"index_size"`
+	InternalPages int64   `json:"index_internal_pages" db:"index_internal_pages"`
+	LeafPages     int64   `json:"index_leaf_pages" db:"index_leaf_pages"`
+	Density       float64 `json:"index_density" db:"index_density"`
+	Fragmentation float64 `json:"index_fragmentation" db:"index_fragmentation"`
+}
+
+const fmtIndexStatsQuery = `SELECT
+	COALESCE(index_size, 0)::bigint AS index_size,
+	COALESCE(internal_pages, 0)::bigint AS index_internal_pages,
+	COALESCE(leaf_pages, 0)::bigint AS index_leaf_pages,
+	COALESCE(avg_leaf_density, 0.0)::decimal AS index_density,
+	COALESCE(leaf_fragmentation, 0.0)::decimal AS index_fragmentation
+FROM pgstatindex('%s');`
@@ -18,7 +18,7 @@ func TestStatsCollection(t *testing.T) {
 	}
 	defer pool.Close()
 
-	generator := NewUUIDv4Generator()
+	generator := NewUUIDv4Generator(TableNameFor("uuidv4"))
 
 	// Clean up and recreate table
 	_ = generator.DropTable(ctx, pool)
@@ -0,0 +1,159 @@
+package ids
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// DefaultInstagramEpoch is the custom epoch from Instagram's post, in milliseconds since the Unix epoch
+	DefaultInstagramEpoch  = 1314220021721
+	DefaultInstagramShards = 8
+	MaxInstagramShards     = 1 << 13
+)
+
+// InstagramGenerator generates Instagram-style sharded IDs in the database: 41 bits of milliseconds since a custom
+// epoch, a 13-bit logical shard ID and 10 bits of a per-shard sequence. Each row is written to a random shard.
+type InstagramGenerator struct {
+	*Table
+
+	shards int
+	epoch  int64
+}
+
+var _ IDGenerator = (*InstagramGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "instagram",
+		Description:  "Instagram-style sharded ID generated in PL/pgSQL",
+		Capabilities: CapabilityDatabase | CapabilityOptions,
+		New:          withOptions(NewInstagramGenerator),
+		Presets:      []string{"shards=1024"},
+	})
+}
+
+// NewInstagramGenerator returns an Instagram ID generator.
+// Supported options: shards (1 to 8192, default 8) and epoch (milliseconds since the Unix epoch, default 1314220021721).
+func NewInstagramGenerator(table string, opts Options) (*InstagramGenerator, error) {
+	if err := opts.Validate("shards", "epoch"); err != nil {
+		return nil, err
+	}
+
+	shards, err := opts.Int("shards", DefaultInstagramShards)
+	if err != nil {
+		return nil, err
+	}
+
+	if shards < 1 || shards > MaxInstagramShards {
+		return nil, fmt.Errorf("instagram shards must be between 1 and %d, got %d", MaxInstagramShards, shards)
+	}
+
+	epoch, err := opts.Int("epoch", DefaultInstagramEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(epoch) > time.Now().UnixMilli() {
+		return nil, fmt.Errorf("instagram epoch %d is in the future", epoch)
+	}
+
+	g := &InstagramGenerator{shards: shards, epoch: int64(epoch)}
+	g.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: fmt.Sprintf("BIGINT DEFAULT instagram_next_id(%d, %d)", g.shards, g.epoch),
+		Setup:    g.setup,
+		Stats: func(_ context.Context, _ *pgxpool.Pool, stats map[string]any) error {
+			stats["instagram_shards"] = g.shards
+			stats["instagram_epoch"] = g.epoch
+			return nil
+		},
+	})
+	return g, nil
+}
+
+func (g *InstagramGenerator) Generate() string {
+	// Instagram IDs are generated by the database
+	return ""
+}
+
+func (g *InstagramGenerator) Name() string {
+	return fmt.Sprintf("Instagram (%d shards) - BIGINT", g.shards)
+}
+
+func (g *InstagramGenerator) Metadata() Metadata {
+	// The shard of each ID is picked at random
+	return Metadata{
+		Bits:                64,
+		RandomBits:          math.Log2(float64(g.shards)),
+		TimestampBits:       41,
+		TimestampResolution: "ms",
+		KSortable:           true,
+		Source:              SourceDatabase,
+		Alphabet:            AlphabetDecimal,
+		Storage:             "BIGINT",
+	}
+}
+
+// setup loads instagram_next_id and creates a sequence for each shard
+func (g *InstagramGenerator) setup(ctx context.Context, pool *pgxpool.Pool) error {
+	err := g.LoadInstagramIDFunction(ctx, pool)
+	if err != nil {
+		return err
+	}
+
+	for shard := 0; shard < g.shards; shard++ {
+		_, err = pool.Exec(ctx, fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS instagram_shard_%d_seq", shard))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *InstagramGenerator) DropTable(ctx context.Context, pool *pgxpool.Pool) error {
+	err := g.Table.DropTable(ctx, pool)
+	if err != nil {
+		return err
+	}
+
+	// Drop the shard sequences too, a previous run may have used a different number of shards
+	_, err = pool.Exec(ctx, `
+	DO $$
+	DECLARE
+		v_sequence TEXT;
+	BEGIN
+		FOR v_sequence IN
+			SELECT sequencename FROM pg_sequences
+			WHERE schemaname = current_schema() AND sequencename LIKE 'instagram\_shard\_%\_seq'
+		LOOP
+			EXECUTE format('DROP SEQUENCE IF EXISTS %I', v_sequence);
+		END LOOP;
+	END $$;
+	`)
+	return err
+}
+
+// LoadInstagramIDFunction creates a PL/pgSQL function that returns an Instagram-style sharded ID.
+// The shard is picked at random and each shard draws from its own sequence, instagram_shard_<n>_seq.
+// Reference: https://instagram-engineering.com/sharding-ids-at-instagram-1cf5a71e5a5c
+func (g *InstagramGenerator) LoadInstagramIDFunction(ctx context.Context, pool *pgxpool.Pool) error {
+	sql := `
+	CREATE OR REPLACE FUNCTION instagram_next_id(p_shards INT, p_epoch BIGINT) RETURNS BIGINT AS $$
+	DECLARE
+		v_shard_id INT := floor(random() * p_shards)::INT;
+		v_now_millis BIGINT := floor(extract(epoch FROM clock_timestamp()) * 1000)::BIGINT;
+		v_seq_id BIGINT;
+	BEGIN
+		v_seq_id := nextval(format('instagram_shard_%s_seq', v_shard_id)::regclass) % 1024;
+		RETURN ((v_now_millis - p_epoch) << 23) | (v_shard_id::BIGINT << 10) | v_seq_id;
+	END $$ LANGUAGE plpgsql VOLATILE;
+	`
+	_, err := pool.Exec(ctx, sql)
+	return err
+}
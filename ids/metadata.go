@@ -0,0 +1,121 @@
+package ids
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+const (
+	// SourceClient means IDs are generated by the client and sent with each insert
+	SourceClient = "client"
+
+	// SourceDatabase means IDs are generated by the database from the column default
+	SourceDatabase = "database"
+)
+
+// Lengths of the decimal string form of 64-bit integers
+const (
+	// maxPositiveInt64Length is the length of the largest non-negative int64, 9223372036854775807
+	maxPositiveInt64Length = 19
+
+	// maxInt64Length is the length of the smallest int64, -9223372036854775808
+	maxInt64Length = 20
+)
+
+// Alphabets of the canonical string forms
+const (
+	AlphabetDecimal   = "decimal"
+	AlphabetHex       = "hex"
+	AlphabetBase32Hex = "base32hex"
+	AlphabetCrockford = "crockford base32"
+	AlphabetBase36    = "base36"
+	AlphabetBase62    = "base62"
+	AlphabetBase64URL = "base64url"
+)
+
+// Metadata describes the IDs a generator produces, so that results can be compared by their properties
+type Metadata struct {
+	// Bits is the size of the ID in its binary form, or the information content of the string, rounded up,
+	// for IDs that only exist as strings
+	Bits int `json:"bits"`
+
+	// RandomBits is the number of bits drawn at random for each ID. It isn't a whole number
+	// when the alphabet size isn't a power of two.
+	RandomBits float64 `json:"random_bits"`
+
+	// TimestampBits is the number of bits taken from the clock, 0 if the ID has no timestamp
+	TimestampBits int `json:"timestamp_bits"`
+
+	// TimestampResolution is the unit of the timestamp, e.g. "ms", empty if the ID has no timestamp
+	TimestampResolution string `json:"timestamp_resolution,omitempty"`
+
+	// KSortable means IDs generated later sort after earlier ones, at least across timestamp ticks
+	KSortable bool `json:"k_sortable"`
+
+	// Source is SourceClient or SourceDatabase
+	Source string `json:"source"`
+
+	// StringLength is the length of the canonical string form, 0 if the length varies
+	StringLength int `json:"string_length"`
+
+	// MaxStringLength is the length of the longest string form when StringLength is 0, 0 if it isn't bounded
+	MaxStringLength int `json:"max_string_length,omitempty"`
+
+	// Alphabet is the alphabet of the canonical string form, one of the Alphabet constants or the characters
+	// of a custom alphabet
+	Alphabet string `json:"alphabet"`
+
+	// Storage is the column type the IDs are stored in
+	Storage string `json:"storage"`
+}
+
+// Set sets the field with the given JSON name from its string form
+func (m *Metadata) Set(name, value string) error {
+	var err error
+	switch name {
+	case "bits":
+		m.Bits, err = strconv.Atoi(value)
+	case "random_bits":
+		m.RandomBits, err = strconv.ParseFloat(value, 64)
+	case "timestamp_bits":
+		m.TimestampBits, err = strconv.Atoi(value)
+	case "timestamp_resolution":
+		m.TimestampResolution = value
+	case "k_sortable":
+		m.KSortable, err = strconv.ParseBool(value)
+	case "source":
+		m.Source = value
+	case "string_length":
+		m.StringLength, err = strconv.Atoi(value)
+	case "max_string_length":
+		m.MaxStringLength, err = strconv.Atoi(value)
+	case "alphabet":
+		m.Alphabet = value
+	case "storage":
+		m.Storage = value
+	default:
+		return fmt.Errorf("unknown metadata field %s", name)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid value for metadata field %s: %w", name, err)
+	}
+	return nil
+}
+
+// randomCharBits returns the number of random bits in n characters drawn uniformly from an alphabet of the given size
+func randomCharBits(n, alphabetSize int) float64 {
+	return float64(n) * math.Log2(float64(alphabetSize))
+}
+
+// uuidMetadata returns the metadata shared by all UUIDs, stored in a UUID column
+func uuidMetadata() Metadata {
+	return Metadata{
+		Bits:         128,
+		Source:       SourceClient,
+		StringLength: 36,
+		Alphabet:     AlphabetHex,
+		Storage:      "UUID",
+	}
+}
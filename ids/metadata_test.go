@@ -0,0 +1,55 @@
+package ids
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataIsConsistent(t *testing.T) {
+	for _, idType := range IDTypes() {
+		key, _, err := ParseIDType(idType)
+		require.NoError(t, err)
+		r, _ := Lookup(key)
+
+		g, err := New(idType)
+		require.NoError(t, err, idType)
+		m := g.Metadata()
+
+		if r.Capabilities.Has(CapabilityClient) {
+			assert.Equal(t, SourceClient, m.Source, idType)
+		} else {
+			assert.Equal(t, SourceDatabase, m.Source, idType)
+		}
+
+		assert.NotEmpty(t, m.Storage, idType)
+		assert.NotEmpty(t, m.Alphabet, idType)
+		assert.Positive(t, m.Bits, idType)
+		assert.LessOrEqual(t, m.RandomBits+float64(m.TimestampBits), float64(m.Bits), idType)
+		assert.Equal(t, m.TimestampBits > 0, m.TimestampResolution != "", idType)
+
+		// The canonical string length matches the generated IDs
+		if m.Source == SourceClient && m.StringLength > 0 {
+			assert.Len(t, g.Generate(), m.StringLength, idType)
+		}
+
+		// No generated ID is longer than the maximum length
+		if m.Source == SourceClient && m.MaxStringLength > 0 {
+			assert.Zero(t, m.StringLength, idType)
+			for i := 0; i < 1000; i++ {
+				assert.LessOrEqual(t, len(g.Generate()), m.MaxStringLength, idType)
+			}
+		}
+	}
+}
+
+func TestMetadataSet(t *testing.T) {
+	var m Metadata
+	require.NoError(t, m.Set("random_bits", "80"))
+	require.NoError(t, m.Set("alphabet", "crockford base32"))
+	assert.Equal(t, Metadata{RandomBits: 80, Alphabet: AlphabetCrockford}, m)
+
+	assert.EqualError(t, m.Set("size", "16"), "unknown metadata field size")
+	assert.Error(t, m.Set("k_sortable", "maybe"))
+}
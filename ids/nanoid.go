@@ -2,91 +2,128 @@ package ids
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"strings"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	gonanoid "github.com/matoous/go-nanoid/v2"
 )
 
-// NanoIDGenerator generates NanoIDs
-type NanoIDGenerator struct{}
+const (
+	// DefaultNanoIDAlphabet is the URL-friendly alphabet NanoID uses unless another one is given
+	DefaultNanoIDAlphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	DefaultNanoIDSize     = 21
+	MaxNanoIDSize         = 255
+)
 
-var _ IDGenerator = (*NanoIDGenerator)(nil)
+// NanoIDGenerator generates NanoIDs with a configurable alphabet and size
+type NanoIDGenerator struct {
+	*Table
 
-func NewNanoIDGenerator() *NanoIDGenerator {
-	return &NanoIDGenerator{}
-}
-
-func (n *NanoIDGenerator) Generate() string {
-	id, err := gonanoid.New()
-	if err != nil {
-		panic(err)
-	}
-	return id
+	alphabet string
+	size     int
 }
 
-func (n *NanoIDGenerator) Name() string {
-	return "NanoID - VARCHAR(21)"
-}
+var _ IDGenerator = (*NanoIDGenerator)(nil)
 
-func (n *NanoIDGenerator) CreateTable(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "CREATE TABLE IF NOT EXISTS nanoid_table (id VARCHAR(21) PRIMARY KEY, n BIGINT NOT NULL)")
-	return err
+func init() {
+	Register(Registration{
+		Key:          "nanoid",
+		Description:  "NanoID with a configurable alphabet and size",
+		Capabilities: CapabilityClient | CapabilityEncodings | CapabilityOptions,
+		New:          withOptions(NewNanoIDGenerator),
+		Presets:      []string{"size=12", "size=8,alphabet=0123456789abcdefghijklmnopqrstuvwxyz"},
+	})
 }
 
-func (n *NanoIDGenerator) DropTable(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "DROP TABLE IF EXISTS nanoid_table")
-	return err
-}
+// NewNanoIDGenerator returns a NanoID generator.
+// Supported options: alphabet (2 to 255 ASCII characters, default URL-friendly) and size (1 to 255, default 21).
+func NewNanoIDGenerator(table string, opts Options) (*NanoIDGenerator, error) {
+	if err := opts.Validate("alphabet", "size"); err != nil {
+		return nil, err
+	}
 
-func (n *NanoIDGenerator) BulkWriteRecords(ctx context.Context, pool *pgxpool.Pool, count uint64) error {
-	batch := &pgx.Batch{}
-	for i := uint64(1); i <= count; i++ {
-		batch.Queue("INSERT INTO nanoid_table (id, n) VALUES ($1, $2)", n.Generate(), i)
+	alphabet := opts.String("alphabet", DefaultNanoIDAlphabet)
+	if len(alphabet) < 2 || len(alphabet) > 255 {
+		return nil, fmt.Errorf("nanoid alphabet must have between 2 and 255 characters, got %d", len(alphabet))
 	}
-	br := pool.SendBatch(ctx, batch)
-	return br.Close()
-}
 
-func (n *NanoIDGenerator) CollectStats(ctx context.Context, pool *pgxpool.Pool) (map[string]any, error) {
-	stats := make(map[string]any)
+	for i := 0; i < len(alphabet); i++ {
+		if alphabet[i] > 127 {
+			return nil, errors.New("nanoid alphabet must only contain ASCII characters")
+		}
+		if strings.IndexByte(alphabet[i+1:], alphabet[i]) >= 0 {
+			return nil, errors.New("nanoid alphabet must not contain duplicate characters")
+		}
+	}
 
-	err := LoadPGStatTuple(ctx, pool)
+	size, err := opts.Int("size", DefaultNanoIDSize)
 	if err != nil {
 		return nil, err
 	}
 
-	var tableStats TableStats
-
-	err = pool.QueryRow(ctx, fmt.Sprintf(fmtStatsQuery, "nanoid_table", "nanoid_table", "nanoid_table")).Scan(
-		&tableStats.TotalTableSize,
-		&tableStats.DataSize,
-		&tableStats.IndexSize,
-		&tableStats.InternalPages,
-		&tableStats.LeafPages,
-		&tableStats.Density,
-		&tableStats.Fragmentation,
-	)
-	if err != nil {
-		return nil, err
+	if size < 1 || size > MaxNanoIDSize {
+		return nil, fmt.Errorf("nanoid size must be between 1 and %d, got %d", MaxNanoIDSize, size)
 	}
 
-	stats["total_table_size"] = tableStats.TotalTableSize
-	stats["data_size"] = tableStats.DataSize
-	stats["index_size"] = tableStats.IndexSize
-	stats["index_internal_pages"] = tableStats.InternalPages
-	stats["index_leaf_pages"] = tableStats.LeafPages
-	stats["index_density"] = tableStats.Density
-	stats["index_fragmentation"] = tableStats.Fragmentation
+	n := &NanoIDGenerator{alphabet: alphabet, size: size}
+	n.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: fmt.Sprintf("VARCHAR(%d)", size),
+		Value:    func(uint64) any { return n.Generate() },
+		Stats: func(_ context.Context, _ *pgxpool.Pool, stats map[string]any) error {
+			stats["nanoid_size"] = n.size
+			stats["nanoid_alphabet"] = n.alphabet
+			stats["nanoid_alphabet_size"] = len(n.alphabet)
+			stats["nanoid_entropy_bits"] = n.entropyBits()
+			return nil
+		},
+	})
+	return n, nil
+}
 
-	// Calculate the ratio of internal pages to leaf pages
-	stats["index_internal_to_leaf_ratio"] = float64(tableStats.InternalPages) / float64(tableStats.LeafPages)
+func (n *NanoIDGenerator) Generate() string {
+	var id string
+	var err error
+	if n.alphabet == DefaultNanoIDAlphabet {
+		// The default alphabet has 64 characters, so every random byte maps to a character
+		id, err = gonanoid.New(n.size)
+	} else {
+		id, err = gonanoid.Generate(n.alphabet, n.size)
+	}
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// entropyBits returns the number of random bits in an ID
+func (n *NanoIDGenerator) entropyBits() float64 {
+	return float64(n.size) * math.Log2(float64(len(n.alphabet)))
+}
 
-	return stats, nil
+func (n *NanoIDGenerator) Name() string {
+	// The alphabet itself can contain characters that aren't safe in result file names, so only its size is shown
+	if n.alphabet == DefaultNanoIDAlphabet && n.size == DefaultNanoIDSize {
+		return fmt.Sprintf("NanoID - VARCHAR(%d)", n.size)
+	}
+	return fmt.Sprintf("NanoID (%d chars, %d-char alphabet) - VARCHAR(%d)", n.size, len(n.alphabet), n.size)
 }
 
-func (n *NanoIDGenerator) InsertRecord(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "INSERT INTO nanoid_table (id, n) VALUES ($1, $2)", n.Generate(), 1)
-	return err
+func (n *NanoIDGenerator) Metadata() Metadata {
+	alphabet := n.alphabet
+	if alphabet == DefaultNanoIDAlphabet {
+		alphabet = AlphabetBase64URL
+	}
+
+	return Metadata{
+		Bits:         int(math.Ceil(n.entropyBits())),
+		RandomBits:   n.entropyBits(),
+		Source:       SourceClient,
+		StringLength: n.size,
+		Alphabet:     alphabet,
+		Storage:      fmt.Sprintf("VARCHAR(%d)", n.size),
+	}
 }
@@ -0,0 +1,72 @@
+package ids
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Options holds the parameters of a configurable generator
+type Options map[string]string
+
+// ParseIDType splits an ID type into its key and options.
+// Options follow the key after a colon as comma-separated name=value pairs, e.g. "cuid2:length=10".
+func ParseIDType(idType string) (string, Options, error) {
+	key, params, found := strings.Cut(idType, ":")
+	opts := make(Options)
+	if !found {
+		return key, opts, nil
+	}
+
+	for _, param := range strings.Split(params, ",") {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok || name == "" {
+			return "", nil, fmt.Errorf("invalid option %q in ID type %s, expected name=value", param, idType)
+		}
+		opts[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+
+	return key, opts, nil
+}
+
+// Validate returns an error if any option is not one of the given names
+func (o Options) Validate(names ...string) error {
+	var unknown []string
+	for name := range o {
+		if !slices.Contains(names, name) {
+			unknown = append(unknown, name)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown options %s, supported options are %s", strings.Join(unknown, ", "), strings.Join(names, ", "))
+	}
+
+	return nil
+}
+
+// String returns the named option, or def if it isn't set
+func (o Options) String(name, def string) string {
+	if value, ok := o[name]; ok {
+		return value
+	}
+	return def
+}
+
+// Int returns the named option as an int, or def if it isn't set
+func (o Options) Int(name string, def int) (int, error) {
+	value, ok := o[name]
+	if !ok {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("option %s must be an integer: %w", name, err)
+	}
+
+	return n, nil
+}
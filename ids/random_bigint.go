@@ -0,0 +1,57 @@
+package ids
+
+import (
+	"encoding/binary"
+	"strconv"
+)
+
+// RandomBigIntGenerator generates crypto-random 64-bit integers stored as BIGINT.
+// It is a baseline that has the randomness of a UUIDv4 in the size of a BIGSERIAL.
+type RandomBigIntGenerator struct {
+	*Table
+}
+
+var _ BinaryGenerator = (*RandomBigIntGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "random-bigint",
+		Description:  "Crypto-random 64-bit integer",
+		Capabilities: CapabilityClient | CapabilityEncodings,
+		New:          withoutOptions(NewRandomBigIntGenerator),
+	})
+}
+
+func NewRandomBigIntGenerator(table string) *RandomBigIntGenerator {
+	return &RandomBigIntGenerator{
+		Table: NewTable(TableSpec{
+			Name:     table,
+			IDColumn: "BIGINT",
+			Value:    func(uint64) any { return randomInt64() },
+		}),
+	}
+}
+
+func (r *RandomBigIntGenerator) Generate() string {
+	return strconv.FormatInt(randomInt64(), 10)
+}
+
+func (r *RandomBigIntGenerator) GenerateBytes() []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(randomInt64()))
+}
+
+func (r *RandomBigIntGenerator) Name() string {
+	return "Random - BIGINT"
+}
+
+func (r *RandomBigIntGenerator) Metadata() Metadata {
+	// Half of the values are negative, so the longest string has a minus sign
+	return Metadata{
+		Bits:            64,
+		RandomBits:      64,
+		Source:          SourceClient,
+		MaxStringLength: maxInt64Length,
+		Alphabet:        AlphabetDecimal,
+		Storage:         "BIGINT",
+	}
+}
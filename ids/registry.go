@@ -0,0 +1,173 @@
+package ids
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Capability describes what a registered generator supports
+type Capability uint
+
+const (
+	// CapabilityClient means IDs are generated by the client and sent with each insert
+	CapabilityClient Capability = 1 << iota
+
+	// CapabilityDatabase means IDs are generated by the database from the column default
+	CapabilityDatabase
+
+	// CapabilityEncodings means the generator's IDs can be stored in other column types.
+	// Text columns take any generator, BYTEA and UUID columns need a BinaryGenerator.
+	CapabilityEncodings
+
+	// CapabilityOptions means the generator takes options after its key, e.g. "cuid2:length=10"
+	CapabilityOptions
+)
+
+var capabilityNames = []struct {
+	capability Capability
+	name       string
+}{
+	{CapabilityClient, "client"},
+	{CapabilityDatabase, "database"},
+	{CapabilityEncodings, "encodings"},
+	{CapabilityOptions, "options"},
+}
+
+// Has reports whether all the given capabilities are set
+func (c Capability) Has(capability Capability) bool {
+	return c&capability == capability
+}
+
+func (c Capability) String() string {
+	var names []string
+	for _, n := range capabilityNames {
+		if c.Has(n.capability) {
+			names = append(names, n.name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Registration describes a generator that can be created by its key
+type Registration struct {
+	Key          string
+	Description  string
+	Capabilities Capability
+
+	// New returns a generator that writes to the given table. The table is named after the ID type, see TableNameFor,
+	// so that generators never share a table. Options are only passed to generators with CapabilityOptions.
+	New func(table string, opts Options) (IDGenerator, error)
+
+	// Presets are option sets that are run by default in addition to the generator's defaults,
+	// e.g. "nodes=32,mode=random"
+	Presets []string
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Registration)
+)
+
+// Register makes a generator available by its key. Generators register themselves from an init function,
+// so packages outside this module can add their own. Register panics if the key is empty, the constructor is nil
+// or the key is already registered.
+func Register(r Registration) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if r.Key == "" || strings.ContainsAny(r.Key, ":,=") {
+		panic(fmt.Sprintf("ids: invalid generator key %q", r.Key))
+	}
+
+	if r.New == nil {
+		panic(fmt.Sprintf("ids: generator %s has no constructor", r.Key))
+	}
+
+	if _, ok := registry[r.Key]; ok {
+		panic(fmt.Sprintf("ids: generator %s is already registered", r.Key))
+	}
+
+	if len(r.Presets) > 0 && !r.Capabilities.Has(CapabilityOptions) {
+		panic(fmt.Sprintf("ids: generator %s has presets but doesn't take options", r.Key))
+	}
+
+	registry[r.Key] = r
+}
+
+// Lookup returns the registration for the given key
+func Lookup(key string) (Registration, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	r, ok := registry[key]
+	return r, ok
+}
+
+// Registrations returns all registered generators, sorted by key
+func Registrations() []Registration {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	registrations := make([]Registration, 0, len(registry))
+	for _, r := range registry {
+		registrations = append(registrations, r)
+	}
+
+	sort.Slice(registrations, func(i, j int) bool {
+		return registrations[i].Key < registrations[j].Key
+	})
+
+	return registrations
+}
+
+// New returns the generator for the given ID type, a registered key optionally followed by options.
+// The generator's table is named after the ID type, see TableNameFor.
+func New(idType string) (IDGenerator, error) {
+	key, opts, err := ParseIDType(idType)
+	if err != nil {
+		return nil, err
+	}
+
+	r, ok := Lookup(key)
+	if !ok {
+		return nil, fmt.Errorf("unknown ID type: %s", idType)
+	}
+
+	if len(opts) > 0 && !r.Capabilities.Has(CapabilityOptions) {
+		return nil, fmt.Errorf("ID type %s does not take options", key)
+	}
+
+	return r.New(TableNameFor(idType), opts)
+}
+
+// IDTypes returns every registered key followed by its presets, e.g. "snowflake" and "snowflake:nodes=32"
+func IDTypes() []string {
+	var idTypes []string
+	for _, r := range Registrations() {
+		idTypes = append(idTypes, r.Key)
+		for _, preset := range r.Presets {
+			idTypes = append(idTypes, r.Key+":"+preset)
+		}
+	}
+	return idTypes
+}
+
+// withOptions adapts a constructor that takes options to Registration.New
+func withOptions[T IDGenerator](fn func(string, Options) (T, error)) func(string, Options) (IDGenerator, error) {
+	return func(table string, opts Options) (IDGenerator, error) {
+		g, err := fn(table, opts)
+		if err != nil {
+			return nil, err
+		}
+		return g, nil
+	}
+}
+
+// withoutOptions adapts a constructor that takes no options to Registration.New
+func withoutOptions[T IDGenerator](fn func(string) T) func(string, Options) (IDGenerator, error) {
+	return func(table string, _ Options) (IDGenerator, error) {
+		return fn(table), nil
+	}
+}
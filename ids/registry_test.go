@@ -0,0 +1,41 @@
+package ids
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	g, err := New("cuid2:length=10")
+	require.NoError(t, err)
+	assert.Equal(t, "Cuid2 (10 chars) - VARCHAR(10)", g.Name())
+
+	_, err = New("uuidv4:length=10")
+	assert.EqualError(t, err, "ID type uuidv4 does not take options")
+
+	_, err = New("unknown")
+	assert.EqualError(t, err, "unknown ID type: unknown")
+}
+
+func TestIDTypesIncludePresets(t *testing.T) {
+	idTypes := IDTypes()
+	assert.Contains(t, idTypes, "snowflake")
+	assert.Contains(t, idTypes, "snowflake:nodes=32,mode=random")
+
+	// Every listed ID type can be created
+	for _, idType := range idTypes {
+		_, err := New(idType)
+		assert.NoError(t, err, idType)
+	}
+}
+
+func TestRegisterPanics(t *testing.T) {
+	newFn := withoutOptions(NewUUIDv4Generator)
+
+	assert.Panics(t, func() { Register(Registration{Key: "uuidv4", New: newFn}) })
+	assert.Panics(t, func() { Register(Registration{Key: "bad:key", New: newFn}) })
+	assert.Panics(t, func() { Register(Registration{Key: "no-constructor"}) })
+	assert.Panics(t, func() { Register(Registration{Key: "no-options", New: newFn, Presets: []string{"a=b"}}) })
+}
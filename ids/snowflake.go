@@ -2,100 +2,123 @@ package ids
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
-	"log"
+	"math/rand/v2"
+	"sync/atomic"
 
 	"github.com/bwmarrin/snowflake"
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// SnowflakeGenerator generates Snowflake IDs
+const (
+	SnowflakeModeRoundRobin = "roundrobin"
+	SnowflakeModeRandom     = "random"
+)
+
+// SnowflakeGenerator generates Snowflake IDs. With more than one node it simulates a fleet of writers
+// by drawing each ID from the nodes in turn or at random, so that IDs arrive interleaved.
 type SnowflakeGenerator struct {
-	node *snowflake.Node
+	*Table
+
+	nodes []*snowflake.Node
+	mode  string
+	next  atomic.Uint64
 }
 
-func NewSnowflakeGenerator() *SnowflakeGenerator {
-	node, err := snowflake.NewNode(1)
+// NewSnowflakeGenerator returns a Snowflake generator.
+// Supported options: nodes (1 to 1024, default 1) and mode (roundrobin or random, default roundrobin).
+func NewSnowflakeGenerator(table string, opts Options) (*SnowflakeGenerator, error) {
+	if err := opts.Validate("nodes", "mode"); err != nil {
+		return nil, err
+	}
+
+	count, err := opts.Int("nodes", 1)
 	if err != nil {
-		log.Fatalf("Failed to create Snowflake node: %v", err)
+		return nil, err
 	}
-	return &SnowflakeGenerator{node: node}
-}
 
-func (s *SnowflakeGenerator) Generate() string {
-	return s.node.Generate().String()
-}
+	if count < 1 || count > 1<<snowflake.NodeBits {
+		return nil, fmt.Errorf("snowflake nodes must be between 1 and %d, got %d", 1<<snowflake.NodeBits, count)
+	}
 
-func (s *SnowflakeGenerator) Name() string {
-	return "Snowflake - BIGINT"
-}
+	mode := opts.String("mode", SnowflakeModeRoundRobin)
+	if mode != SnowflakeModeRoundRobin && mode != SnowflakeModeRandom {
+		return nil, fmt.Errorf("snowflake mode must be %s or %s, got %s", SnowflakeModeRoundRobin, SnowflakeModeRandom, mode)
+	}
 
-var _ IDGenerator = (*SnowflakeGenerator)(nil)
+	nodes := make([]*snowflake.Node, count)
+	for i := range nodes {
+		// Node IDs start at 1 so that a single node matches the original generator
+		nodes[i], err = snowflake.NewNode(int64(i+1) % (1 << snowflake.NodeBits))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Snowflake node: %w", err)
+		}
+	}
 
-func (s *SnowflakeGenerator) CreateTable(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "CREATE TABLE IF NOT EXISTS snowflake_table (id BIGINT PRIMARY KEY, n BIGINT NOT NULL)")
-	return err
+	s := &SnowflakeGenerator{nodes: nodes, mode: mode}
+	s.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "BIGINT",
+		Value:    func(uint64) any { return s.node().Generate().Int64() },
+		Stats: func(_ context.Context, _ *pgxpool.Pool, stats map[string]any) error {
+			stats["snowflake_nodes"] = len(s.nodes)
+			stats["snowflake_mode"] = s.mode
+			return nil
+		},
+	})
+	return s, nil
 }
 
-func (s *SnowflakeGenerator) DropTable(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "DROP TABLE IF EXISTS snowflake_table")
-	return err
-}
+// node returns the node the next ID is drawn from
+func (s *SnowflakeGenerator) node() *snowflake.Node {
+	if len(s.nodes) == 1 {
+		return s.nodes[0]
+	}
+
+	if s.mode == SnowflakeModeRandom {
+		return s.nodes[rand.IntN(len(s.nodes))]
+	}
 
-func (s *SnowflakeGenerator) InsertRecords(pool *pgxpool.Pool, count int64) error {
-	// Method removed as it is not part of the IDGenerator interface
-	return nil
+	return s.nodes[(s.next.Add(1)-1)%uint64(len(s.nodes))]
 }
 
-func (s *SnowflakeGenerator) BulkWriteRecords(ctx context.Context, pool *pgxpool.Pool, count uint64) error {
-	batch := &pgx.Batch{}
-	for i := uint64(1); i <= count; i++ {
-		id := s.node.Generate().Int64()
-		batch.Queue("INSERT INTO snowflake_table (id, n) VALUES ($1, $2)", id, i)
-	}
-	br := pool.SendBatch(ctx, batch)
-	return br.Close()
+func (s *SnowflakeGenerator) Generate() string {
+	return s.node().Generate().String()
 }
 
-func (s *SnowflakeGenerator) CollectStats(ctx context.Context, pool *pgxpool.Pool) (map[string]any, error) {
-	stats := make(map[string]any)
+func (s *SnowflakeGenerator) GenerateBytes() []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(s.node().Generate().Int64()))
+}
 
-	err := LoadPGStatTuple(ctx, pool)
-	if err != nil {
-		return nil, err
+func (s *SnowflakeGenerator) Name() string {
+	if len(s.nodes) == 1 {
+		return "Snowflake - BIGINT"
 	}
+	return fmt.Sprintf("Snowflake (%d nodes, %s) - BIGINT", len(s.nodes), s.mode)
+}
 
-	var tableStats TableStats
-
-	err = pool.QueryRow(ctx, fmt.Sprintf(fmtStatsQuery, "snowflake_table", "snowflake_table", "snowflake_table")).Scan(
-		&tableStats.TotalTableSize,
-		&tableStats.DataSize,
-		&tableStats.IndexSize,
-		&tableStats.InternalPages,
-		&tableStats.LeafPages,
-		&tableStats.Density,
-		&tableStats.Fragmentation,
-	)
-	if err != nil {
-		return nil, err
+func (s *SnowflakeGenerator) Metadata() Metadata {
+	return Metadata{
+		Bits:                64,
+		TimestampBits:       41,
+		TimestampResolution: "ms",
+		KSortable:           true,
+		Source:              SourceClient,
+		MaxStringLength:     maxPositiveInt64Length,
+		Alphabet:            AlphabetDecimal,
+		Storage:             "BIGINT",
 	}
-
-	stats["total_table_size"] = tableStats.TotalTableSize
-	stats["data_size"] = tableStats.DataSize
-	stats["index_size"] = tableStats.IndexSize
-	stats["index_internal_pages"] = tableStats.InternalPages
-	stats["index_leaf_pages"] = tableStats.LeafPages
-	stats["index_density"] = tableStats.Density
-	stats["index_fragmentation"] = tableStats.Fragmentation
-
-	// Calculate the ratio of internal pages to leaf pages
-	stats["index_internal_to_leaf_ratio"] = float64(tableStats.InternalPages) / float64(tableStats.LeafPages)
-
-	return stats, nil
 }
 
-func (s *SnowflakeGenerator) InsertRecord(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "INSERT INTO snowflake_table (id, n) VALUES ($1, $2)", s.node.Generate().Int64(), 1)
-	return err
+var _ BinaryGenerator = (*SnowflakeGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "snowflake",
+		Description:  "Twitter Snowflake, optionally from several interleaved nodes",
+		Capabilities: CapabilityClient | CapabilityEncodings | CapabilityOptions,
+		New:          withOptions(NewSnowflakeGenerator),
+		Presets:      []string{"nodes=32,mode=roundrobin", "nodes=32,mode=random"},
+	})
 }
@@ -0,0 +1,80 @@
+package ids
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/sony/sonyflake"
+)
+
+// SonyflakeGenerator generates Sonyflake IDs
+type SonyflakeGenerator struct {
+	*Table
+
+	flake *sonyflake.Sonyflake
+}
+
+var _ BinaryGenerator = (*SonyflakeGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "sonyflake",
+		Description:  "Sonyflake 63-bit time-ordered ID",
+		Capabilities: CapabilityClient | CapabilityEncodings,
+		New: withOptions(func(table string, _ Options) (*SonyflakeGenerator, error) {
+			return NewSonyflakeGenerator(table)
+		}),
+	})
+}
+
+func NewSonyflakeGenerator(table string) (*SonyflakeGenerator, error) {
+	// The default machine ID is derived from the private IP address, which isn't always available in containers
+	flake, err := sonyflake.New(sonyflake.Settings{
+		MachineID: func() (uint16, error) { return 1, nil },
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Sonyflake: %w", err)
+	}
+	s := &SonyflakeGenerator{flake: flake}
+	s.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "BIGINT",
+		Value:    func(uint64) any { return s.next() },
+	})
+	return s, nil
+}
+
+// next returns the next Sonyflake ID. Sonyflake only fails once its 39-bit time space is exhausted, which is in 2188.
+func (s *SonyflakeGenerator) next() int64 {
+	id, err := s.flake.NextID()
+	if err != nil {
+		panic(err)
+	}
+	return int64(id)
+}
+
+func (s *SonyflakeGenerator) Generate() string {
+	return strconv.FormatInt(s.next(), 10)
+}
+
+func (s *SonyflakeGenerator) GenerateBytes() []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(s.next()))
+}
+
+func (s *SonyflakeGenerator) Name() string {
+	return "Sonyflake - BIGINT"
+}
+
+func (s *SonyflakeGenerator) Metadata() Metadata {
+	return Metadata{
+		Bits:                64,
+		TimestampBits:       39,
+		TimestampResolution: "10ms",
+		KSortable:           true,
+		Source:              SourceClient,
+		MaxStringLength:     maxPositiveInt64Length,
+		Alphabet:            AlphabetDecimal,
+		Storage:             "BIGINT",
+	}
+}
@@ -0,0 +1,193 @@
+package ids
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sqids/sqids-go"
+)
+
+// DefaultSqidsAlphabet is the alphabet used by Sqids unless another one is given
+const DefaultSqidsAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// sqidsCodec encodes a single non-negative integer as a Sqid and decodes it back.
+// It uses the default blocklist, so IDs that would contain profanity are re-encoded as the library does.
+type sqidsCodec struct {
+	sqids *sqids.Sqids
+
+	// alphabet is the alphabet given in the options
+	alphabet string
+}
+
+func newSqidsCodec(opts Options) (*sqidsCodec, error) {
+	alphabet := opts.String("alphabet", DefaultSqidsAlphabet)
+
+	minLength, err := opts.Int("minlength", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if minLength < 0 || minLength > 255 {
+		return nil, fmt.Errorf("sqids minlength must be between 0 and 255, got %d", minLength)
+	}
+
+	s, err := sqids.New(sqids.Options{Alphabet: alphabet, MinLength: uint8(minLength)})
+	if err != nil {
+		return nil, fmt.Errorf("invalid sqids options: %w", err)
+	}
+
+	return &sqidsCodec{sqids: s, alphabet: alphabet}, nil
+}
+
+// metadataAlphabet returns the alphabet as it is reported in Metadata
+func (c *sqidsCodec) metadataAlphabet() string {
+	if c.alphabet == DefaultSqidsAlphabet {
+		return AlphabetBase62
+	}
+	return c.alphabet
+}
+
+func (c *sqidsCodec) encode(n uint64) (string, error) {
+	return c.sqids.Encode([]uint64{n})
+}
+
+func (c *sqidsCodec) decode(id string) (uint64, error) {
+	numbers := c.sqids.Decode(id)
+	if len(numbers) != 1 {
+		return 0, fmt.Errorf("sqid %s does not decode to a single number", id)
+	}
+	return numbers[0], nil
+}
+
+// SqidsGenerator stores BIGSERIAL IDs and measures the client-side cost of encoding them to their public
+// Sqids form and decoding them back
+type SqidsGenerator struct {
+	*Table
+
+	codec *sqidsCodec
+
+	encodeTime time.Duration
+	decodeTime time.Duration
+	encoded    uint64
+}
+
+var _ IDGenerator = (*SqidsGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "sqids",
+		Description:  "BIGSERIAL encoded to Sqids and back on the client",
+		Capabilities: CapabilityDatabase | CapabilityOptions,
+		New:          withOptions(NewSqidsGenerator),
+	})
+}
+
+// NewSqidsGenerator returns a Sqids generator. Supported options: alphabet and minlength (default 0).
+func NewSqidsGenerator(table string, opts Options) (*SqidsGenerator, error) {
+	if err := opts.Validate("alphabet", "minlength"); err != nil {
+		return nil, err
+	}
+
+	codec, err := newSqidsCodec(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SqidsGenerator{codec: codec}
+	s.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "BIGSERIAL",
+		Stats:    s.roundTripStats,
+	})
+	return s, nil
+}
+
+func (s *SqidsGenerator) Generate() string {
+	// The bigint is generated by the database, only its public form is encoded on the client
+	return ""
+}
+
+func (s *SqidsGenerator) Name() string {
+	return "Sqids (BIGSERIAL) - BIGINT"
+}
+
+func (s *SqidsGenerator) Metadata() Metadata {
+	// The Sqid is the public form, its length depends on the value
+	return Metadata{
+		Bits:      64,
+		KSortable: true,
+		Source:    SourceDatabase,
+		Alphabet:  s.codec.metadataAlphabet(),
+		Storage:   "BIGINT",
+	}
+}
+
+func (s *SqidsGenerator) InsertRecord(ctx context.Context, pool *pgxpool.Pool) error {
+	var id int64
+	err := pool.QueryRow(ctx, fmt.Sprintf("INSERT INTO %s (n) VALUES (1) RETURNING id", s.TableName())).Scan(&id)
+	if err != nil {
+		return err
+	}
+	return s.roundTrip(uint64(id))
+}
+
+// BulkWriteRecords inserts the rows and round-trips every returned ID through its public form,
+// as an API would when it hands the IDs out and receives them back
+func (s *SqidsGenerator) BulkWriteRecords(ctx context.Context, pool *pgxpool.Pool, count uint64) error {
+	s.encodeTime, s.decodeTime, s.encoded = 0, 0, 0
+
+	rows, err := pool.Query(ctx, fmt.Sprintf("INSERT INTO %s (n) SELECT g.n FROM generate_series(1, $1) AS g(n) RETURNING id", s.TableName()), count)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+
+		if err := s.roundTrip(uint64(id)); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (s *SqidsGenerator) roundTrip(id uint64) error {
+	start := time.Now()
+	public, err := s.codec.encode(id)
+	if err != nil {
+		return err
+	}
+	encoded := time.Now()
+	decoded, err := s.codec.decode(public)
+	s.decodeTime += time.Since(encoded)
+	s.encodeTime += encoded.Sub(start)
+	s.encoded++
+
+	if err != nil {
+		return err
+	}
+
+	if decoded != id {
+		return fmt.Errorf("sqid %s decoded to %d, expected %d", public, decoded, id)
+	}
+
+	return nil
+}
+
+// roundTripStats adds the client-side cost of the public form
+func (s *SqidsGenerator) roundTripStats(_ context.Context, _ *pgxpool.Pool, stats map[string]any) error {
+	stats["encode_time_ms"] = float64(s.encodeTime.Microseconds()) / 1000
+	stats["decode_time_ms"] = float64(s.decodeTime.Microseconds()) / 1000
+	if s.encoded > 0 {
+		stats["encode_ns_per_id"] = float64(s.encodeTime.Nanoseconds()) / float64(s.encoded)
+		stats["decode_ns_per_id"] = float64(s.decodeTime.Nanoseconds()) / float64(s.encoded)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package ids
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqidsCodec(t *testing.T) {
+	codec, err := newSqidsCodec(Options{})
+	require.NoError(t, err)
+
+	// Test vectors from the Sqids specification
+	for n, expected := range []string{"bM", "Uk", "gb", "Ef", "Vq", "uw", "OI", "AX", "p6", "nJ"} {
+		id, err := codec.encode(uint64(n))
+		require.NoError(t, err)
+		assert.Equal(t, expected, id)
+	}
+
+	for _, n := range []uint64{0, 1, 61, 62, 1_000_000, 1<<63 - 1} {
+		id, err := codec.encode(n)
+		require.NoError(t, err)
+
+		decoded, err := codec.decode(id)
+		require.NoError(t, err)
+		assert.Equal(t, n, decoded)
+	}
+}
+
+func TestSqidsCodecBlocklist(t *testing.T) {
+	codec, err := newSqidsCodec(Options{})
+	require.NoError(t, err)
+
+	// Test vector from the Sqids specification, 4572721 would otherwise encode to a blocked word
+	id, err := codec.encode(4572721)
+	require.NoError(t, err)
+	assert.Equal(t, "JExTR", id)
+}
+
+func TestSqidsCodecMinLength(t *testing.T) {
+	codec, err := newSqidsCodec(Options{"minlength": "10"})
+	require.NoError(t, err)
+
+	for _, n := range []uint64{0, 1, 1_000_000} {
+		id, err := codec.encode(n)
+		require.NoError(t, err)
+		assert.Len(t, id, 10)
+
+		decoded, err := codec.decode(id)
+		require.NoError(t, err)
+		assert.Equal(t, n, decoded)
+	}
+}
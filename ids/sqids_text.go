@@ -0,0 +1,99 @@
+package ids
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SqidsTextGenerator encodes a client-side sequence with Sqids and stores the encoded string as the primary key
+type SqidsTextGenerator struct {
+	*Table
+
+	codec *sqidsCodec
+
+	// next is the client-side sequence, it restarts whenever the table is created
+	next atomic.Uint64
+
+	encodeTime time.Duration
+	encoded    uint64
+}
+
+var _ IDGenerator = (*SqidsTextGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "sqids-text",
+		Description:  "Sqids of a client-side sequence stored as TEXT",
+		Capabilities: CapabilityClient | CapabilityOptions,
+		New:          withOptions(NewSqidsTextGenerator),
+	})
+}
+
+// NewSqidsTextGenerator returns a Sqids generator that stores the encoded form.
+// Supported options: alphabet and minlength (default 0).
+func NewSqidsTextGenerator(table string, opts Options) (*SqidsTextGenerator, error) {
+	if err := opts.Validate("alphabet", "minlength"); err != nil {
+		return nil, err
+	}
+
+	codec, err := newSqidsCodec(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SqidsTextGenerator{codec: codec}
+	s.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "TEXT",
+		Setup: func(context.Context, *pgxpool.Pool) error {
+			s.next.Store(0)
+			return nil
+		},
+		Value: func(uint64) any { return s.Generate() },
+		Stats: s.encodeStats,
+	})
+	return s, nil
+}
+
+func (s *SqidsTextGenerator) Generate() string {
+	start := time.Now()
+	id, err := s.codec.encode(s.next.Add(1))
+	if err != nil {
+		// Encoding only fails once the blocklist has rejected every variant of the number
+		panic(err)
+	}
+	s.encodeTime += time.Since(start)
+	s.encoded++
+	return id
+}
+
+func (s *SqidsTextGenerator) Name() string {
+	return "Sqids - TEXT"
+}
+
+func (s *SqidsTextGenerator) Metadata() Metadata {
+	return Metadata{
+		Bits:     64,
+		Source:   SourceClient,
+		Alphabet: s.codec.metadataAlphabet(),
+		Storage:  "TEXT",
+	}
+}
+
+func (s *SqidsTextGenerator) BulkWriteRecords(ctx context.Context, pool *pgxpool.Pool, count uint64) error {
+	s.encodeTime, s.encoded = 0, 0
+
+	return s.Table.BulkWriteRecords(ctx, pool, count)
+}
+
+// encodeStats adds the client-side cost of encoding the sequence
+func (s *SqidsTextGenerator) encodeStats(_ context.Context, _ *pgxpool.Pool, stats map[string]any) error {
+	stats["encode_time_ms"] = float64(s.encodeTime.Microseconds()) / 1000
+	if s.encoded > 0 {
+		stats["encode_ns_per_id"] = float64(s.encodeTime.Nanoseconds()) / float64(s.encoded)
+	}
+	return nil
+}
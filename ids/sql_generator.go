@@ -0,0 +1,223 @@
+package ids
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// sqlDirective prefixes the comment lines that define a generator in a .sql file
+const sqlDirective = "-- compareids:"
+
+// SQLDefinition describes a DB-side generator defined in an annotated .sql file:
+//
+//	-- compareids:key ulid-db
+//	-- compareids:name ULID (DB) - VARCHAR(26)
+//	-- compareids:column VARCHAR(26)
+//	-- compareids:default generate_ulid()
+//	-- compareids:stat ulid_entropy pgcrypto
+//	-- compareids:meta random_bits 80
+//	-- compareids:setup
+//	CREATE OR REPLACE FUNCTION generate_ulid() ...
+//	-- compareids:end
+//
+// The setup section runs before the table is created, anything outside it is ignored,
+// so the same file can still be run as a standalone script. Each stat directive adds a name and value to the stats.
+// Each meta directive sets a Metadata field by its JSON name. The source is always the database and the storage
+// defaults to the column type.
+type SQLDefinition struct {
+	File     string
+	Key      string
+	Name     string
+	Column   string
+	Default  string
+	Setup    string
+	Stats    map[string]string
+	Metadata Metadata
+}
+
+// ParseSQLDefinitions returns the generators defined in the .sql files at the root of fsys.
+// Files without a key directive are skipped.
+func ParseSQLDefinitions(fsys fs.FS) ([]SQLDefinition, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions []SQLDefinition
+	keys := make(map[string]string)
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		def, err := parseSQLDefinition(file, string(data))
+		if err != nil {
+			return nil, err
+		}
+
+		if def.Key == "" {
+			continue
+		}
+
+		if other, ok := keys[def.Key]; ok {
+			return nil, fmt.Errorf("%s: key %s is already defined in %s", file, def.Key, other)
+		}
+		keys[def.Key] = file
+
+		definitions = append(definitions, def)
+	}
+
+	return definitions, nil
+}
+
+func parseSQLDefinition(file, data string) (SQLDefinition, error) {
+	def := SQLDefinition{File: file, Metadata: Metadata{Source: SourceDatabase}}
+
+	var setup strings.Builder
+	inSetup := false
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+
+		rest, isDirective := strings.CutPrefix(strings.TrimSpace(text), sqlDirective)
+		if !isDirective {
+			if inSetup {
+				setup.WriteString(text)
+				setup.WriteByte('\n')
+			}
+			continue
+		}
+
+		directive, value, _ := strings.Cut(rest, " ")
+		value = strings.TrimSpace(value)
+		if value == "" && directive != "setup" && directive != "end" {
+			return def, fmt.Errorf("%s:%d: directive %s needs a value", file, line, directive)
+		}
+
+		switch directive {
+		case "key":
+			def.Key = value
+		case "name":
+			def.Name = value
+		case "column":
+			def.Column = value
+		case "default":
+			def.Default = value
+		case "stat":
+			name, statValue, ok := strings.Cut(value, " ")
+			if !ok {
+				return def, fmt.Errorf("%s:%d: stat directive needs a name and a value", file, line)
+			}
+			if def.Stats == nil {
+				def.Stats = make(map[string]string)
+			}
+			def.Stats[name] = strings.TrimSpace(statValue)
+		case "meta":
+			name, metaValue, ok := strings.Cut(value, " ")
+			if !ok {
+				return def, fmt.Errorf("%s:%d: meta directive needs a name and a value", file, line)
+			}
+			if err := def.Metadata.Set(name, strings.TrimSpace(metaValue)); err != nil {
+				return def, fmt.Errorf("%s:%d: %w", file, line, err)
+			}
+		case "setup":
+			inSetup = true
+		case "end":
+			inSetup = false
+		default:
+			return def, fmt.Errorf("%s:%d: unknown directive %s", file, line, directive)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return def, err
+	}
+
+	if inSetup {
+		return def, fmt.Errorf("%s: setup section is not closed with %send", file, sqlDirective)
+	}
+
+	if def.Key == "" {
+		return def, nil
+	}
+
+	if def.Column == "" {
+		return def, fmt.Errorf("%s: generator %s has no column directive", file, def.Key)
+	}
+
+	if def.Name == "" {
+		def.Name = fmt.Sprintf("%s (SQL) - %s", def.Key, strings.ToUpper(def.Column))
+	}
+
+	if def.Metadata.Storage == "" {
+		def.Metadata.Storage = strings.ToUpper(def.Column)
+	}
+
+	def.Setup = setup.String()
+
+	return def, nil
+}
+
+// SQLGenerator generates IDs in the database with a generator defined in a .sql file
+type SQLGenerator struct {
+	*Table
+
+	def SQLDefinition
+}
+
+var _ IDGenerator = (*SQLGenerator)(nil)
+
+func NewSQLGenerator(table string, def SQLDefinition) *SQLGenerator {
+	column := def.Column
+	if def.Default != "" {
+		column += " DEFAULT " + def.Default
+	}
+
+	s := &SQLGenerator{def: def}
+	s.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: column,
+		Setup:    s.setup,
+		Stats: func(_ context.Context, _ *pgxpool.Pool, stats map[string]any) error {
+			stats["sql_file"] = s.def.File
+			for name, value := range s.def.Stats {
+				stats[name] = value
+			}
+			return nil
+		},
+	})
+	return s
+}
+
+func (s *SQLGenerator) Generate() string {
+	// IDs are generated by the database using the column default
+	return ""
+}
+
+func (s *SQLGenerator) Name() string {
+	return s.def.Name
+}
+
+func (s *SQLGenerator) Metadata() Metadata {
+	return s.def.Metadata
+}
+
+// setup runs the setup section of the .sql file
+func (s *SQLGenerator) setup(ctx context.Context, pool *pgxpool.Pool) error {
+	if strings.TrimSpace(s.def.Setup) == "" {
+		return nil
+	}
+
+	_, err := pool.Exec(ctx, s.def.Setup)
+	if err != nil {
+		return fmt.Errorf("%s: setup failed: %w", s.def.File, err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package ids
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSQLDefinitions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"serial.sql": {Data: []byte(`
+-- compareids:key serial-sql
+-- compareids:column BIGINT
+-- compareids:default nextval('serial_sql_seq')
+-- compareids:stat sequence shared
+-- compareids:meta bits 64
+-- compareids:meta k_sortable true
+drop sequence if exists serial_sql_seq;
+-- compareids:setup
+CREATE SEQUENCE IF NOT EXISTS serial_sql_seq;
+-- compareids:end
+select 1;
+`)},
+		"script.sql": {Data: []byte("select 1;\n")},
+	}
+
+	definitions, err := ParseSQLDefinitions(fsys)
+	require.NoError(t, err)
+	require.Len(t, definitions, 1)
+
+	def := definitions[0]
+	assert.Equal(t, "serial.sql", def.File)
+	assert.Equal(t, "serial-sql", def.Key)
+	assert.Equal(t, "serial-sql (SQL) - BIGINT", def.Name)
+	assert.Equal(t, "nextval('serial_sql_seq')", def.Default)
+	assert.Equal(t, "CREATE SEQUENCE IF NOT EXISTS serial_sql_seq;\n", def.Setup)
+	assert.Equal(t, map[string]string{"sequence": "shared"}, def.Stats)
+	assert.Equal(t, Metadata{Bits: 64, KSortable: true, Source: SourceDatabase, Storage: "BIGINT"}, def.Metadata)
+}
+
+func TestParseSQLDefinitionsErrors(t *testing.T) {
+	tests := map[string]string{
+		"unclosed setup":    "-- compareids:key a\n-- compareids:column UUID\n-- compareids:setup\nselect 1;\n",
+		"missing column":    "-- compareids:key a\n",
+		"unknown directive": "-- compareids:key a\n-- compareids:type UUID\n",
+		"empty value":       "-- compareids:key\n",
+		"unknown metadata":  "-- compareids:key a\n-- compareids:column UUID\n-- compareids:meta size 16\n",
+		"invalid metadata":  "-- compareids:key a\n-- compareids:column UUID\n-- compareids:meta bits many\n",
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseSQLDefinitions(fstest.MapFS{"a.sql": {Data: []byte(data)}})
+			assert.Error(t, err)
+		})
+	}
+}
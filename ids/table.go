@@ -0,0 +1,159 @@
+package ids
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TableSpec describes the table a generator writes its IDs to.
+// Every table has an id primary key and an n BIGINT column that holds the row number.
+type TableSpec struct {
+	// Name is the table name, the one the registry passes to the generator's constructor
+	Name string
+
+	// IDColumn is the type of the id column and anything that follows it except the primary key,
+	// e.g. "UUID" or "UUID DEFAULT gen_random_uuid()"
+	IDColumn string
+
+	// Columns are definitions added after the n column, e.g. "u UUID NOT NULL" or a table constraint
+	Columns []string
+
+	// Setup runs before the table is created, e.g. to load a function used by the column default
+	Setup func(ctx context.Context, pool *pgxpool.Pool) error
+
+	// Value produces the ID for row n on the client, in the form it is bound to the insert.
+	// It is nil when the database generates the ID from the column default.
+	Value func(n uint64) any
+
+	// Stats adds generator-specific values to the stats collected for every table
+	Stats func(ctx context.Context, pool *pgxpool.Pool, stats map[string]any) error
+}
+
+// Table implements the table lifecycle and stats of an IDGenerator from its TableSpec.
+// Generators embed it and only implement Generate and Name, overriding any method that needs to differ.
+type Table struct {
+	spec TableSpec
+}
+
+func NewTable(spec TableSpec) *Table {
+	return &Table{spec: spec}
+}
+
+// maxIdentifierLength is the length PostgreSQL truncates identifiers to
+const maxIdentifierLength = 63
+
+// TableNameFor returns the table name for an ID type, e.g. "snowflake_nodes_32_table" for "snowflake:nodes=32".
+// Names that would be truncated by PostgreSQL are shortened and suffixed with a hash of the ID type instead.
+func TableNameFor(idType string) string {
+	name := tableKey(idType) + "_table"
+	if len(name) <= maxIdentifierLength {
+		return name
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(idType))
+	return fmt.Sprintf("%s_%08x_table", tableKey(idType)[:maxIdentifierLength-len("_00000000_table")], h.Sum32())
+}
+
+// TableName returns the name of the table
+func (t *Table) TableName() string {
+	return t.spec.Name
+}
+
+func (t *Table) CreateTable(ctx context.Context, pool *pgxpool.Pool) error {
+	if t.spec.Setup != nil {
+		if err := t.spec.Setup(ctx, pool); err != nil {
+			return err
+		}
+	}
+
+	columns := append([]string{"id " + t.spec.IDColumn + " PRIMARY KEY", "n BIGINT NOT NULL"}, t.spec.Columns...)
+	_, err := pool.Exec(ctx, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", t.spec.Name, strings.Join(columns, ", ")))
+	return err
+}
+
+func (t *Table) DropTable(ctx context.Context, pool *pgxpool.Pool) error {
+	_, err := pool.Exec(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", t.spec.Name))
+	return err
+}
+
+func (t *Table) InsertRecord(ctx context.Context, pool *pgxpool.Pool) error {
+	if t.spec.Value == nil {
+		_, err := pool.Exec(ctx, fmt.Sprintf("INSERT INTO %s (n) VALUES (1)", t.spec.Name))
+		return err
+	}
+
+	_, err := pool.Exec(ctx, fmt.Sprintf("INSERT INTO %s (id, n) VALUES ($1, $2)", t.spec.Name), t.spec.Value(1), 1)
+	return err
+}
+
+// BulkWriteRecords inserts count rows. Client-side IDs are sent in a single batch of inserts,
+// IDs generated by the database are inserted with one statement.
+func (t *Table) BulkWriteRecords(ctx context.Context, pool *pgxpool.Pool, count uint64) error {
+	if t.spec.Value == nil {
+		_, err := pool.Exec(ctx, fmt.Sprintf("INSERT INTO %s (n) SELECT g.n FROM generate_series(1, $1) AS g(n)", t.spec.Name), count)
+		return err
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (id, n) VALUES ($1, $2)", t.spec.Name)
+	batch := &pgx.Batch{}
+	for i := uint64(1); i <= count; i++ {
+		batch.Queue(query, t.spec.Value(i), i)
+	}
+	br := pool.SendBatch(ctx, batch)
+	return br.Close()
+}
+
+// specStats adds only the generator-specific stats, without the table stats that need the database to exist
+func (t *Table) specStats(ctx context.Context, pool *pgxpool.Pool, stats map[string]any) error {
+	if t.spec.Stats == nil {
+		return nil
+	}
+	return t.spec.Stats(ctx, pool, stats)
+}
+
+func (t *Table) CollectStats(ctx context.Context, pool *pgxpool.Pool) (map[string]any, error) {
+	stats := make(map[string]any)
+
+	err := LoadPGStatTuple(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	var tableStats TableStats
+
+	err = pool.QueryRow(ctx, fmt.Sprintf(fmtStatsQuery, t.spec.Name, t.spec.Name, t.spec.Name)).Scan(
+		&tableStats.TotalTableSize,
+		&tableStats.DataSize,
+		&tableStats.IndexSize,
+		&tableStats.InternalPages,
+		&tableStats.LeafPages,
+		&tableStats.Density,
+		&tableStats.Fragmentation,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	stats["total_table_size"] = tableStats.TotalTableSize
+	stats["data_size"] = tableStats.DataSize
+	stats["index_size"] = tableStats.IndexSize
+	stats["index_internal_pages"] = tableStats.InternalPages
+	stats["index_leaf_pages"] = tableStats.LeafPages
+	stats["index_density"] = tableStats.Density
+	stats["index_fragmentation"] = tableStats.Fragmentation
+
+	// Calculate the ratio of internal pages to leaf pages
+	stats["index_internal_to_leaf_ratio"] = float64(tableStats.InternalPages) / float64(tableStats.LeafPages)
+
+	if err := t.specStats(ctx, pool, stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,48 @@
+package ids
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratorsUseTable(t *testing.T) {
+	for _, idType := range IDTypes() {
+		g, err := New(idType)
+		require.NoError(t, err, idType)
+
+		table, ok := g.(interface{ TableName() string })
+		if assert.True(t, ok, "%s doesn't embed *Table", idType) {
+			assert.Regexp(t, `^[a-z0-9_]+_table$`, table.TableName(), idType)
+		}
+	}
+}
+
+func TestNameBasedValueUsesRowNumber(t *testing.T) {
+	g, err := NewUUIDv5Generator(TableNameFor("uuidv5"), nil)
+	require.NoError(t, err)
+
+	// The same row number always gives the same ID
+	assert.Equal(t, g.GenerateFor(42), g.spec.Value(42))
+}
+
+func TestTableNameFor(t *testing.T) {
+	assert.Equal(t, "uuidv4_db_table", TableNameFor("uuidv4-db"))
+	assert.Equal(t, "snowflake_nodes_32_mode_random_table", TableNameFor("snowflake:nodes=32,mode=random"))
+
+	// Long ID types are shortened to fit PostgreSQL's identifier limit, but stay distinct
+	long := TableNameFor("nanoid:size=8,alphabet=0123456789abcdefghijklmnopqrstuvwxyz")
+	assert.Len(t, long, maxIdentifierLength)
+	assert.NotEqual(t, long, TableNameFor("nanoid:size=8,alphabet=0123456789abcdefghijklmnopqrstuvwxyZ"))
+}
+
+func TestNewNamesTablesAfterIDType(t *testing.T) {
+	client, err := New("uuidv4")
+	require.NoError(t, err)
+	db, err := New("uuidv4-db")
+	require.NoError(t, err)
+
+	assert.Equal(t, "uuidv4_table", client.(*UUIDv4Generator).TableName())
+	assert.Equal(t, "uuidv4_db_table", db.(*UUIDv4DBGenerator).TableName())
+}
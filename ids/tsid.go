@@ -0,0 +1,126 @@
+package ids
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// tsidEpoch is 2020-01-01T00:00:00Z in milliseconds, the epoch used by tsid-creator
+	tsidEpoch       = 1577836800000
+	tsidNodeBits    = 10
+	tsidCounterBits = 12
+	tsidCounterMask = 1<<tsidCounterBits - 1
+
+	// crockfordAlphabet is Crockford's Base32 alphabet, as used by TSID and ULID
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// tsidFactory creates TSIDs: 42 bits of milliseconds since tsidEpoch, followed by a 10-bit node ID and a 12-bit
+// counter. The counter starts at a random value every millisecond and borrows the next millisecond on overflow.
+// Reference: https://github.com/f4b6a3/tsid-creator
+type tsidFactory struct {
+	mu      sync.Mutex
+	node    int64
+	lastMs  int64
+	counter int64
+}
+
+func newTSIDFactory(node int64) *tsidFactory {
+	return &tsidFactory{node: node & (1<<tsidNodeBits - 1)}
+}
+
+func (f *tsidFactory) next() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ms := time.Now().UnixMilli() - tsidEpoch
+	if ms > f.lastMs {
+		f.lastMs = ms
+		f.counter = randomInt64() & tsidCounterMask
+	} else {
+		f.counter++
+		if f.counter > tsidCounterMask {
+			f.lastMs++
+			f.counter = 0
+		}
+	}
+
+	return f.lastMs<<(tsidNodeBits+tsidCounterBits) | f.node<<tsidCounterBits | f.counter
+}
+
+// formatTSID returns the canonical 13-character Crockford Base32 form of a TSID
+func formatTSID(id int64) string {
+	var s [13]byte
+	for i := range s {
+		s[i] = crockfordAlphabet[(uint64(id)>>(60-5*i))&31]
+	}
+	return string(s[:])
+}
+
+// randomInt64 returns a cryptographically random int64
+func randomInt64() int64 {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return int64(binary.BigEndian.Uint64(b[:]))
+}
+
+// TSIDGenerator generates TSIDs stored as BIGINT
+type TSIDGenerator struct {
+	*Table
+
+	factory *tsidFactory
+}
+
+var _ BinaryGenerator = (*TSIDGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "tsid",
+		Description:  "Time-sorted ID stored as BIGINT",
+		Capabilities: CapabilityClient | CapabilityEncodings,
+		New:          withoutOptions(NewTSIDGenerator),
+	})
+}
+
+func NewTSIDGenerator(table string) *TSIDGenerator {
+	t := &TSIDGenerator{factory: newTSIDFactory(1)}
+	t.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "BIGINT",
+		Value:    func(uint64) any { return t.factory.next() },
+	})
+	return t
+}
+
+func (t *TSIDGenerator) Generate() string {
+	return strconv.FormatInt(t.factory.next(), 10)
+}
+
+func (t *TSIDGenerator) GenerateBytes() []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(t.factory.next()))
+}
+
+func (t *TSIDGenerator) Name() string {
+	return "TSID - BIGINT"
+}
+
+func (t *TSIDGenerator) Metadata() Metadata {
+	// The counter starts at a random value each millisecond
+	return Metadata{
+		Bits:                64,
+		RandomBits:          tsidCounterBits,
+		TimestampBits:       42,
+		TimestampResolution: "ms",
+		KSortable:           true,
+		Source:              SourceClient,
+		MaxStringLength:     maxPositiveInt64Length,
+		Alphabet:            AlphabetDecimal,
+		Storage:             "BIGINT",
+	}
+}
@@ -0,0 +1,26 @@
+package ids
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatTSID(t *testing.T) {
+	assert.Equal(t, "0000000000000", formatTSID(0))
+	assert.Equal(t, "0000000000001", formatTSID(1))
+	assert.Equal(t, "7ZZZZZZZZZZZZ", formatTSID(math.MaxInt64))
+}
+
+func TestTSIDFactoryIsMonotonic(t *testing.T) {
+	factory := newTSIDFactory(1)
+
+	prev := factory.next()
+	for i := 0; i < 10_000; i++ {
+		id := factory.next()
+		assert.Greater(t, id, prev)
+		assert.Less(t, formatTSID(prev), formatTSID(id))
+		prev = id
+	}
+}
@@ -0,0 +1,51 @@
+package ids
+
+// TSIDTextGenerator generates TSIDs stored in their canonical 13-character Crockford Base32 form.
+// It has no binary form of its own, its bytes would be those of the tsid generator.
+type TSIDTextGenerator struct {
+	*Table
+	factory *tsidFactory
+}
+
+var _ IDGenerator = (*TSIDTextGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "tsid-text",
+		Description:  "Time-sorted ID stored as its 13-character string",
+		Capabilities: CapabilityClient | CapabilityEncodings,
+		New:          withoutOptions(NewTSIDTextGenerator),
+	})
+}
+
+func NewTSIDTextGenerator(table string) *TSIDTextGenerator {
+	t := &TSIDTextGenerator{factory: newTSIDFactory(1)}
+	t.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "VARCHAR(13)",
+		Value:    func(uint64) any { return t.Generate() },
+	})
+	return t
+}
+
+func (t *TSIDTextGenerator) Generate() string {
+	return formatTSID(t.factory.next())
+}
+
+func (t *TSIDTextGenerator) Name() string {
+	return "TSID (Crockford) - VARCHAR(13)"
+}
+
+func (t *TSIDTextGenerator) Metadata() Metadata {
+	return Metadata{
+		Bits:                64,
+		RandomBits:          tsidCounterBits,
+		TimestampBits:       42,
+		TimestampResolution: "ms",
+		KSortable:           true,
+		Source:              SourceClient,
+		StringLength:        13,
+		Alphabet:            AlphabetCrockford,
+		Storage:             "VARCHAR(13)",
+	}
+}
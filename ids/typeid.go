@@ -3,97 +3,144 @@ package ids
 import (
 	"context"
 	"fmt"
+	"strings"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.jetify.com/typeid"
 )
 
-// TypeIDGenerator generates TypeIDs
-type TypeIDGenerator struct{}
+const (
+	TypeIDStorageString = "string"
+	TypeIDStorageUUID   = "uuid"
 
-var _ IDGenerator = (*TypeIDGenerator)(nil)
+	// typeIDSuffixLength is the length of the base32 suffix that encodes the UUID
+	typeIDSuffixLength = 26
+)
+
+// TypeIDGenerator generates TypeIDs with an optional type prefix, e.g. user_01h455vb4pex5vsknk084sn02q.
+// The ID is either stored as its full string, or the prefix is dropped and the suffix is stored as a native UUID.
+type TypeIDGenerator struct {
+	*Table
 
-func NewTypeIDGenerator() *TypeIDGenerator {
-	return &TypeIDGenerator{}
+	prefix  string
+	storage string
 }
 
-type CustomPrefix struct{}
+var _ BinaryGenerator = (*TypeIDGenerator)(nil)
 
-func (CustomPrefix) Prefix() string {
-	return ""
+func init() {
+	Register(Registration{
+		Key:          "typeid",
+		Description:  "TypeID with an optional prefix, stored as a string or a UUID",
+		Capabilities: CapabilityClient | CapabilityEncodings | CapabilityOptions,
+		New:          withOptions(NewTypeIDGenerator),
+		Presets:      []string{"prefix=user", "prefix=user,storage=uuid"},
+	})
 }
 
-func (t *TypeIDGenerator) Generate() string {
-	id, err := typeid.New[typeid.TypeID[CustomPrefix]]()
+// NewTypeIDGenerator returns a TypeID generator.
+// Supported options: prefix (default none) and storage (string or uuid, default string).
+func NewTypeIDGenerator(table string, opts Options) (*TypeIDGenerator, error) {
+	if err := opts.Validate("prefix", "storage"); err != nil {
+		return nil, err
+	}
+
+	prefix := opts.String("prefix", "")
+	if _, err := typeid.WithPrefix(prefix); err != nil {
+		return nil, fmt.Errorf("invalid typeid prefix: %w", err)
+	}
+
+	storage := opts.String("storage", TypeIDStorageString)
+	if storage != TypeIDStorageString && storage != TypeIDStorageUUID {
+		return nil, fmt.Errorf("typeid storage must be %s or %s, got %s", TypeIDStorageString, TypeIDStorageUUID, storage)
+	}
+
+	t := &TypeIDGenerator{prefix: prefix, storage: storage}
+	t.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: t.column(),
+		Value:    func(uint64) any { return t.value() },
+		Stats: func(_ context.Context, _ *pgxpool.Pool, stats map[string]any) error {
+			stats["typeid_prefix"] = t.prefix
+			stats["typeid_storage"] = t.storage
+			return nil
+		},
+	})
+	return t, nil
+}
+
+func (t *TypeIDGenerator) newID() typeid.AnyID {
+	id, err := typeid.WithPrefix(t.prefix)
 	if err != nil {
 		panic(err)
 	}
-	return id.String()
+	return id
 }
 
-func (t *TypeIDGenerator) Name() string {
-	return "TypeID - VARCHAR(27)"
+// length returns the length of the full string form, the prefix is followed by an underscore
+func (t *TypeIDGenerator) length() int {
+	if t.prefix == "" {
+		return typeIDSuffixLength
+	}
+	return len(t.prefix) + 1 + typeIDSuffixLength
 }
 
-func (t *TypeIDGenerator) CreateTable(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "CREATE TABLE IF NOT EXISTS typeid_table (id VARCHAR(27) PRIMARY KEY, n BIGINT NOT NULL)")
-	return err
+func (t *TypeIDGenerator) Generate() string {
+	return t.newID().String()
 }
 
-func (t *TypeIDGenerator) DropTable(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "DROP TABLE IF EXISTS typeid_table")
-	return err
+func (t *TypeIDGenerator) GenerateBytes() []byte {
+	return t.newID().UUIDBytes()
 }
 
-func (t *TypeIDGenerator) InsertRecord(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "INSERT INTO typeid_table (id, n) VALUES ($1, $2)", t.Generate(), 1)
-	return err
+// value returns the ID in the form it is stored in
+func (t *TypeIDGenerator) value() string {
+	if t.storage == TypeIDStorageUUID {
+		return t.newID().UUID()
+	}
+	return t.Generate()
 }
 
-func (t *TypeIDGenerator) BulkWriteRecords(ctx context.Context, pool *pgxpool.Pool, count uint64) error {
-	batch := &pgx.Batch{}
-	for i := uint64(1); i <= count; i++ {
-		id := t.Generate()
-		batch.Queue("INSERT INTO typeid_table (id, n) VALUES ($1, $2)", id, i)
+// checkEncoding rejects text and BYTEA encodings of the uuid storage, they would store the full string
+// or the same bytes as the string storage, under a name that says only the UUID suffix is stored
+func (t *TypeIDGenerator) checkEncoding(encoding Encoding) error {
+	if t.storage == TypeIDStorageUUID && encoding != EncodingUUID {
+		return fmt.Errorf("%w: typeid with storage=uuid can only be stored as uuid, use storage=string for %s", ErrUnsupportedEncoding, encoding)
 	}
-	br := pool.SendBatch(ctx, batch)
-	return br.Close()
+	return nil
 }
 
-func (t *TypeIDGenerator) CollectStats(ctx context.Context, pool *pgxpool.Pool) (map[string]any, error) {
-	stats := make(map[string]any)
-
-	err := LoadPGStatTuple(ctx, pool)
-	if err != nil {
-		return nil, err
+func (t *TypeIDGenerator) column() string {
+	if t.storage == TypeIDStorageUUID {
+		return "UUID"
 	}
+	return fmt.Sprintf("VARCHAR(%d)", t.length())
+}
 
-	var tableStats TableStats
-
-	err = pool.QueryRow(ctx, fmt.Sprintf(fmtStatsQuery, "typeid_table", "typeid_table", "typeid_table")).Scan(
-		&tableStats.TotalTableSize,
-		&tableStats.DataSize,
-		&tableStats.IndexSize,
-		&tableStats.InternalPages,
-		&tableStats.LeafPages,
-		&tableStats.Density,
-		&tableStats.Fragmentation,
-	)
-	if err != nil {
-		return nil, err
+func (t *TypeIDGenerator) Name() string {
+	var params []string
+	if t.prefix != "" {
+		params = append(params, t.prefix)
+	}
+	if t.storage == TypeIDStorageUUID {
+		params = append(params, "UUID suffix")
 	}
 
-	stats["total_table_size"] = tableStats.TotalTableSize
-	stats["data_size"] = tableStats.DataSize
-	stats["index_size"] = tableStats.IndexSize
-	stats["index_internal_pages"] = tableStats.InternalPages
-	stats["index_leaf_pages"] = tableStats.LeafPages
-	stats["index_density"] = tableStats.Density
-	stats["index_fragmentation"] = tableStats.Fragmentation
-
-	// Calculate the ratio of internal pages to leaf pages
-	stats["index_internal_to_leaf_ratio"] = float64(tableStats.InternalPages) / float64(tableStats.LeafPages)
+	if len(params) == 0 {
+		return fmt.Sprintf("TypeID - %s", t.column())
+	}
+	return fmt.Sprintf("TypeID (%s) - %s", strings.Join(params, ", "), t.column())
+}
 
-	return stats, nil
+func (t *TypeIDGenerator) Metadata() Metadata {
+	// The suffix is a UUIDv7
+	m := uuidMetadata()
+	m.RandomBits = 74
+	m.TimestampBits = 48
+	m.TimestampResolution = "ms"
+	m.KSortable = true
+	m.StringLength = t.length()
+	m.Alphabet = AlphabetCrockford
+	m.Storage = t.column()
+	return m
 }
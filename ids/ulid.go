@@ -2,87 +2,116 @@ package ids
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
+	"sync"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/oklog/ulid/v2"
 )
 
-// ULIDGenerator generates ULID IDs
-type ULIDGenerator struct{}
+const (
+	// ULIDEntropyMake uses ulid.Make, which draws from a process-wide monotonic source seeded from math/rand
+	ULIDEntropyMake = "make"
 
-var _ IDGenerator = (*ULIDGenerator)(nil)
+	// ULIDEntropyRandom reads fresh entropy from crypto/rand for every ID, so IDs from the same millisecond
+	// are not ordered
+	ULIDEntropyRandom = "random"
 
-func NewULIDGenerator() *ULIDGenerator {
-	return &ULIDGenerator{}
-}
+	// ULIDEntropyMonotonic increments the entropy of the previous ID within the same millisecond, reading from
+	// crypto/rand through ulid.Monotonic
+	ULIDEntropyMonotonic = "monotonic"
+)
 
-func (u *ULIDGenerator) Generate() string {
-	return ulid.Make().String()
-}
+// ULIDGenerator generates ULID IDs
+type ULIDGenerator struct {
+	*Table
 
-func (u *ULIDGenerator) Name() string {
-	return "ULID - VARCHAR(26)"
-}
+	entropy string
 
-func (u *ULIDGenerator) CreateTable(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "CREATE TABLE IF NOT EXISTS ulid_table (id TEXT PRIMARY KEY, n BIGINT NOT NULL)")
-	return err
+	mu        sync.Mutex
+	monotonic *ulid.MonotonicEntropy
 }
 
-func (u *ULIDGenerator) DropTable(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "DROP TABLE IF EXISTS ulid_table")
-	return err
-}
+var _ BinaryGenerator = (*ULIDGenerator)(nil)
 
-func (u *ULIDGenerator) BulkWriteRecords(ctx context.Context, pool *pgxpool.Pool, count uint64) error {
-	batch := &pgx.Batch{}
-	for i := uint64(1); i <= count; i++ {
-		batch.Queue("INSERT INTO ulid_table (id, n) VALUES ($1, $2)", u.Generate(), i)
-	}
-	br := pool.SendBatch(ctx, batch)
-	return br.Close()
+func init() {
+	Register(Registration{
+		Key:          "ulid",
+		Description:  "ULID with a choice of entropy source",
+		Capabilities: CapabilityClient | CapabilityEncodings | CapabilityOptions,
+		New:          withOptions(NewULIDGenerator),
+		Presets:      []string{"entropy=random", "entropy=monotonic"},
+	})
 }
 
-func (u *ULIDGenerator) CollectStats(ctx context.Context, pool *pgxpool.Pool) (map[string]any, error) {
-	stats := make(map[string]any)
-
-	err := LoadPGStatTuple(ctx, pool)
-	if err != nil {
+// NewULIDGenerator returns a ULID generator. Supported options: entropy (make, random or monotonic, default make).
+func NewULIDGenerator(table string, opts Options) (*ULIDGenerator, error) {
+	if err := opts.Validate("entropy"); err != nil {
 		return nil, err
 	}
 
-	var tableStats TableStats
-
-	err = pool.QueryRow(ctx, fmt.Sprintf(fmtStatsQuery, "ulid_table", "ulid_table", "ulid_table")).Scan(
-		&tableStats.TotalTableSize,
-		&tableStats.DataSize,
-		&tableStats.IndexSize,
-		&tableStats.InternalPages,
-		&tableStats.LeafPages,
-		&tableStats.Density,
-		&tableStats.Fragmentation,
-	)
-	if err != nil {
-		return nil, err
+	entropy := opts.String("entropy", ULIDEntropyMake)
+	switch entropy {
+	case ULIDEntropyMake, ULIDEntropyRandom, ULIDEntropyMonotonic:
+	default:
+		return nil, fmt.Errorf("ulid entropy must be one of %s, %s or %s, got %s",
+			ULIDEntropyMake, ULIDEntropyRandom, ULIDEntropyMonotonic, entropy)
 	}
 
-	stats["total_table_size"] = tableStats.TotalTableSize
-	stats["data_size"] = tableStats.DataSize
-	stats["index_size"] = tableStats.IndexSize
-	stats["index_internal_pages"] = tableStats.InternalPages
-	stats["index_leaf_pages"] = tableStats.LeafPages
-	stats["index_density"] = tableStats.Density
-	stats["index_fragmentation"] = tableStats.Fragmentation
+	u := &ULIDGenerator{entropy: entropy, monotonic: ulid.Monotonic(rand.Reader, 0)}
+	u.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "TEXT",
+		Value:    func(uint64) any { return u.Generate() },
+		Stats: func(_ context.Context, _ *pgxpool.Pool, stats map[string]any) error {
+			stats["ulid_entropy"] = u.entropy
+			return nil
+		},
+	})
+	return u, nil
+}
+
+func (u *ULIDGenerator) next() ulid.ULID {
+	switch u.entropy {
+	case ULIDEntropyRandom:
+		return ulid.MustNew(ulid.Now(), rand.Reader)
+	case ULIDEntropyMonotonic:
+		// The monotonic source isn't safe for concurrent use
+		u.mu.Lock()
+		defer u.mu.Unlock()
+		return ulid.MustNew(ulid.Now(), u.monotonic)
+	default:
+		return ulid.Make()
+	}
+}
 
-	// Calculate the ratio of internal pages to leaf pages
-	stats["index_internal_to_leaf_ratio"] = float64(tableStats.InternalPages) / float64(tableStats.LeafPages)
+func (u *ULIDGenerator) Generate() string {
+	return u.next().String()
+}
 
-	return stats, nil
+func (u *ULIDGenerator) GenerateBytes() []byte {
+	id := u.next()
+	return id[:]
 }
 
-func (u *ULIDGenerator) InsertRecord(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "INSERT INTO ulid_table (id, n) VALUES ($1, $2)", u.Generate(), 1)
-	return err
+func (u *ULIDGenerator) Name() string {
+	if u.entropy == ULIDEntropyMake {
+		return "ULID - TEXT"
+	}
+	return fmt.Sprintf("ULID (%s) - TEXT", u.entropy)
+}
+
+func (u *ULIDGenerator) Metadata() Metadata {
+	return Metadata{
+		Bits:                128,
+		RandomBits:          80,
+		TimestampBits:       48,
+		TimestampResolution: "ms",
+		KSortable:           true,
+		Source:              SourceClient,
+		StringLength:        26,
+		Alphabet:            AlphabetCrockford,
+		Storage:             "TEXT",
+	}
 }
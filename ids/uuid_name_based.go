@@ -0,0 +1,117 @@
+package ids
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NameBasedUUIDGenerator generates deterministic UUIDv3 or UUIDv5 IDs by hashing a namespace and the row's n value,
+// so that repeated runs produce the same keys
+type NameBasedUUIDGenerator struct {
+	*Table
+
+	version   byte
+	namespace uuid.UUID
+
+	// next is the n value used by Generate, which isn't given a row
+	next atomic.Uint64
+}
+
+var _ IDGenerator = (*NameBasedUUIDGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "uuidv3",
+		Description:  "RFC 9562 UUIDv3, MD5 of a namespace and name",
+		Capabilities: CapabilityClient | CapabilityOptions,
+		New:          withOptions(NewUUIDv3Generator),
+	})
+	Register(Registration{
+		Key:          "uuidv5",
+		Description:  "RFC 9562 UUIDv5, SHA-1 of a namespace and name",
+		Capabilities: CapabilityClient | CapabilityOptions,
+		New:          withOptions(NewUUIDv5Generator),
+	})
+}
+
+// NewUUIDv5Generator returns a generator for SHA-1 name-based UUIDs.
+// Supported options: namespace (dns, url, oid, x500 or a UUID, default url).
+func NewUUIDv5Generator(table string, opts Options) (*NameBasedUUIDGenerator, error) {
+	return newNameBasedUUIDGenerator(table, uuid.V5, opts)
+}
+
+// NewUUIDv3Generator returns a generator for MD5 name-based UUIDs.
+// Supported options: namespace (dns, url, oid, x500 or a UUID, default url).
+func NewUUIDv3Generator(table string, opts Options) (*NameBasedUUIDGenerator, error) {
+	return newNameBasedUUIDGenerator(table, uuid.V3, opts)
+}
+
+func newNameBasedUUIDGenerator(table string, version byte, opts Options) (*NameBasedUUIDGenerator, error) {
+	if err := opts.Validate("namespace"); err != nil {
+		return nil, err
+	}
+
+	namespace, err := parseNamespace(opts.String("namespace", "url"))
+	if err != nil {
+		return nil, err
+	}
+
+	u := &NameBasedUUIDGenerator{version: version, namespace: namespace}
+	u.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "UUID",
+		Value:    func(n uint64) any { return u.GenerateFor(n) },
+		Stats: func(_ context.Context, _ *pgxpool.Pool, stats map[string]any) error {
+			stats["uuid_namespace"] = u.namespace.String()
+			return nil
+		},
+	})
+	return u, nil
+}
+
+// parseNamespace returns one of the RFC 9562 predefined namespaces by name, or parses a custom namespace UUID
+func parseNamespace(name string) (uuid.UUID, error) {
+	switch name {
+	case "dns":
+		return uuid.NamespaceDNS, nil
+	case "url":
+		return uuid.NamespaceURL, nil
+	case "oid":
+		return uuid.NamespaceOID, nil
+	case "x500":
+		return uuid.NamespaceX500, nil
+	}
+
+	namespace, err := uuid.FromString(name)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("namespace must be dns, url, oid, x500 or a UUID: %w", err)
+	}
+	return namespace, nil
+}
+
+// GenerateFor returns the ID derived from the given n value
+func (u *NameBasedUUIDGenerator) GenerateFor(n uint64) string {
+	name := strconv.FormatUint(n, 10)
+	if u.version == uuid.V3 {
+		return uuid.NewV3(u.namespace, name).String()
+	}
+	return uuid.NewV5(u.namespace, name).String()
+}
+
+func (u *NameBasedUUIDGenerator) Generate() string {
+	return u.GenerateFor(u.next.Add(1))
+}
+
+func (u *NameBasedUUIDGenerator) Name() string {
+	return fmt.Sprintf("UUIDv%d - UUID", u.version)
+}
+
+func (u *NameBasedUUIDGenerator) Metadata() Metadata {
+	// IDs are derived from the row, so none of their bits are random
+	return uuidMetadata()
+}
@@ -0,0 +1,58 @@
+package ids
+
+import "github.com/gofrs/uuid/v5"
+
+// UUIDv1Generator generates UUIDv1 IDs, which store the low bits of the timestamp first
+type UUIDv1Generator struct {
+	*Table
+}
+
+var _ BinaryGenerator = (*UUIDv1Generator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "uuidv1",
+		Description:  "RFC 9562 UUIDv1, Gregorian time and node",
+		Capabilities: CapabilityClient | CapabilityEncodings,
+		New:          withoutOptions(NewUUIDv1Generator),
+	})
+}
+
+func NewUUIDv1Generator(table string) *UUIDv1Generator {
+	u := &UUIDv1Generator{}
+	u.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "UUID",
+		Value:    func(uint64) any { return u.Generate() },
+	})
+	return u
+}
+
+func (u *UUIDv1Generator) Generate() string {
+	id, err := uuid.NewV1()
+	if err != nil {
+		panic(err)
+	}
+	return id.String()
+}
+
+func (u *UUIDv1Generator) GenerateBytes() []byte {
+	id, err := uuid.NewV1()
+	if err != nil {
+		panic(err)
+	}
+	return id.Bytes()
+}
+
+func (u *UUIDv1Generator) Name() string {
+	return "UUIDv1 - UUID"
+}
+
+func (u *UUIDv1Generator) Metadata() Metadata {
+	// The timestamp's low bits come first, so IDs don't sort by time. The clock sequence is random,
+	// but drawn once per process.
+	m := uuidMetadata()
+	m.TimestampBits = 60
+	m.TimestampResolution = "100ns"
+	return m
+}
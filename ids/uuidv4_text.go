@@ -0,0 +1,45 @@
+package ids
+
+import "github.com/google/uuid"
+
+// UUIDv4TextGenerator generates UUIDv4 IDs and stores them as VARCHAR(36)
+type UUIDv4TextGenerator struct {
+	*Table
+}
+
+var _ IDGenerator = (*UUIDv4TextGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "uuidv4-text",
+		Description:  "Random UUIDv4 stored as VARCHAR(36)",
+		Capabilities: CapabilityClient,
+		New:          withoutOptions(NewUUIDv4TextGenerator),
+	})
+}
+
+func NewUUIDv4TextGenerator(table string) *UUIDv4TextGenerator {
+	u := &UUIDv4TextGenerator{}
+	u.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "VARCHAR(36)",
+		Value:    func(uint64) any { return u.Generate() },
+	})
+	return u
+}
+
+func (u *UUIDv4TextGenerator) Generate() string {
+	return uuid.NewString()
+}
+
+func (u *UUIDv4TextGenerator) Name() string {
+	// uuidv4 --encoding varchar is named "UUIDv4 - VARCHAR(36)"
+	return "UUIDv4 (text) - VARCHAR(36)"
+}
+
+func (u *UUIDv4TextGenerator) Metadata() Metadata {
+	m := uuidMetadata()
+	m.RandomBits = 122
+	m.Storage = "VARCHAR(36)"
+	return m
+}
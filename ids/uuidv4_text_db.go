@@ -0,0 +1,43 @@
+package ids
+
+// UUIDv4TextDBGenerator generates UUIDv4 IDs using the database and stores them as VARCHAR(36)
+type UUIDv4TextDBGenerator struct {
+	*Table
+}
+
+var _ IDGenerator = (*UUIDv4TextDBGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "uuidv4-text-db",
+		Description:  "gen_random_uuid() stored as VARCHAR(36)",
+		Capabilities: CapabilityDatabase,
+		New:          withoutOptions(NewUUIDv4TextDBGenerator),
+	})
+}
+
+func NewUUIDv4TextDBGenerator(table string) *UUIDv4TextDBGenerator {
+	return &UUIDv4TextDBGenerator{
+		Table: NewTable(TableSpec{
+			Name:     table,
+			IDColumn: "VARCHAR(36) DEFAULT gen_random_uuid()::text",
+		}),
+	}
+}
+
+func (u *UUIDv4TextDBGenerator) Generate() string {
+	// UUIDv4 is generated by the database
+	return ""
+}
+
+func (u *UUIDv4TextDBGenerator) Name() string {
+	return "UUIDv4 (DB) - VARCHAR(36)"
+}
+
+func (u *UUIDv4TextDBGenerator) Metadata() Metadata {
+	m := uuidMetadata()
+	m.RandomBits = 122
+	m.Source = SourceDatabase
+	m.Storage = "VARCHAR(36)"
+	return m
+}
@@ -0,0 +1,58 @@
+package ids
+
+import "github.com/gofrs/uuid/v5"
+
+// UUIDv6Generator generates UUIDv6 IDs, which reorder the UUIDv1 timestamp so it is big-endian
+type UUIDv6Generator struct {
+	*Table
+}
+
+var _ BinaryGenerator = (*UUIDv6Generator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "uuidv6",
+		Description:  "RFC 9562 UUIDv6, reordered Gregorian time",
+		Capabilities: CapabilityClient | CapabilityEncodings,
+		New:          withoutOptions(NewUUIDv6Generator),
+	})
+}
+
+func NewUUIDv6Generator(table string) *UUIDv6Generator {
+	u := &UUIDv6Generator{}
+	u.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "UUID",
+		Value:    func(uint64) any { return u.Generate() },
+	})
+	return u
+}
+
+func (u *UUIDv6Generator) Generate() string {
+	id, err := uuid.NewV6()
+	if err != nil {
+		panic(err)
+	}
+	return id.String()
+}
+
+func (u *UUIDv6Generator) GenerateBytes() []byte {
+	id, err := uuid.NewV6()
+	if err != nil {
+		panic(err)
+	}
+	return id.Bytes()
+}
+
+func (u *UUIDv6Generator) Name() string {
+	return "UUIDv6 - UUID"
+}
+
+func (u *UUIDv6Generator) Metadata() Metadata {
+	m := uuidMetadata()
+	m.RandomBits = 62
+	m.TimestampBits = 60
+	m.TimestampResolution = "100ns"
+	m.KSortable = true
+	return m
+}
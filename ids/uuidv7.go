@@ -2,97 +2,161 @@ package ids
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// UUIDv7Generator generates UUIDv7 IDs
-type UUIDv7Generator struct{}
+const (
+	// UUIDv7MethodLibrary uses github.com/gofrs/uuid, which puts a 12-bit clock sequence in rand_a.
+	// The sequence starts at a random value and increments within a millisecond, but it wraps instead of
+	// advancing the timestamp.
+	UUIDv7MethodLibrary = "gofrs"
 
-var _ IDGenerator = (*UUIDv7Generator)(nil)
+	// UUIDv7MethodRandom fills rand_a and rand_b with random bits, RFC 9562 section 5.7.
+	// IDs are only ordered across milliseconds.
+	UUIDv7MethodRandom = "random"
 
-func NewUUIDv7Generator() *UUIDv7Generator {
-	return &UUIDv7Generator{}
-}
+	// UUIDv7MethodSubMs puts the fraction of the millisecond in rand_a as 12 bits of extra clock precision,
+	// RFC 9562 section 6.2, method 3.
+	UUIDv7MethodSubMs = "subms"
 
-func (u *UUIDv7Generator) Generate() string {
-	id, err := uuid.NewV7()
-	if err != nil {
-		panic(err)
-	}
-	return id.String()
-}
+	// UUIDv7MethodCounter puts a 12-bit counter in rand_a, RFC 9562 section 6.2, method 1. The counter starts at a
+	// random value each millisecond and advances the timestamp when it overflows, so IDs are strictly increasing.
+	UUIDv7MethodCounter = "counter"
+)
 
-func (u *UUIDv7Generator) Name() string {
-	return "UUIDv7 - UUID"
-}
+// uuidv7SubMsResolution is the resolution of a millisecond timestamp extended by 12 bits, 1/4096 of a millisecond
+const uuidv7SubMsResolution = "244ns"
 
-func (u *UUIDv7Generator) CreateTable(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "CREATE TABLE IF NOT EXISTS uuidv7_table (id UUID PRIMARY KEY, n BIGINT NOT NULL)")
-	return err
-}
+// UUIDv7Generator generates UUIDv7 IDs, using the method given for the bits after the timestamp
+type UUIDv7Generator struct {
+	*Table
+
+	method string
 
-func (u *UUIDv7Generator) DropTable(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "DROP TABLE IF EXISTS uuidv7_table")
-	return err
+	mu      sync.Mutex
+	lastMs  int64
+	counter uint16
 }
 
-func (u *UUIDv7Generator) InsertRecords(pool *pgxpool.Pool, count int64) error {
-	// Method removed as it is not part of the IDGenerator interface
-	return nil
+var _ BinaryGenerator = (*UUIDv7Generator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "uuidv7",
+		Description:  "RFC 9562 UUIDv7 with a choice of method for the bits after the timestamp",
+		Capabilities: CapabilityClient | CapabilityEncodings | CapabilityOptions,
+		New:          withOptions(NewUUIDv7Generator),
+		Presets:      []string{"method=random", "method=subms", "method=counter"},
+	})
 }
 
-func (u *UUIDv7Generator) BulkWriteRecords(ctx context.Context, pool *pgxpool.Pool, count uint64) error {
-	batch := &pgx.Batch{}
-	for i := uint64(1); i <= count; i++ {
-		id := u.Generate()
-		batch.Queue("INSERT INTO uuidv7_table (id, n) VALUES ($1, $2)", id, i)
+// NewUUIDv7Generator returns a UUIDv7 generator. Supported options: method (gofrs, random, subms or counter,
+// default gofrs).
+func NewUUIDv7Generator(table string, opts Options) (*UUIDv7Generator, error) {
+	if err := opts.Validate("method"); err != nil {
+		return nil, err
+	}
+
+	method := opts.String("method", UUIDv7MethodLibrary)
+	switch method {
+	case UUIDv7MethodLibrary, UUIDv7MethodRandom, UUIDv7MethodSubMs, UUIDv7MethodCounter:
+	default:
+		return nil, fmt.Errorf("uuidv7 method must be one of %s, %s, %s or %s, got %s",
+			UUIDv7MethodLibrary, UUIDv7MethodRandom, UUIDv7MethodSubMs, UUIDv7MethodCounter, method)
 	}
-	br := pool.SendBatch(ctx, batch)
-	return br.Close()
+
+	u := &UUIDv7Generator{method: method}
+	u.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "UUID",
+		Value:    func(uint64) any { return u.Generate() },
+		Stats: func(_ context.Context, _ *pgxpool.Pool, stats map[string]any) error {
+			stats["uuidv7_method"] = u.method
+			return nil
+		},
+	})
+	return u, nil
 }
 
-func (u *UUIDv7Generator) CollectStats(ctx context.Context, pool *pgxpool.Pool) (map[string]any, error) {
-	stats := make(map[string]any)
+func (u *UUIDv7Generator) Generate() string {
+	return uuid.Must(uuid.FromBytes(u.GenerateBytes())).String()
+}
 
-	err := LoadPGStatTuple(ctx, pool)
-	if err != nil {
-		return nil, err
+func (u *UUIDv7Generator) GenerateBytes() []byte {
+	if u.method == UUIDv7MethodLibrary {
+		id, err := uuid.NewV7()
+		if err != nil {
+			panic(err)
+		}
+		return id.Bytes()
 	}
 
-	var tableStats TableStats
-
-	err = pool.QueryRow(ctx, fmt.Sprintf(fmtStatsQuery, "uuidv7_table", "uuidv7_table", "uuidv7_table")).Scan(
-		&tableStats.TotalTableSize,
-		&tableStats.DataSize,
-		&tableStats.IndexSize,
-		&tableStats.InternalPages,
-		&tableStats.LeafPages,
-		&tableStats.Density,
-		&tableStats.Fragmentation,
-	)
-	if err != nil {
-		return nil, err
+	now := time.Now()
+	ms := now.UnixMilli()
+
+	var randA uint64
+	switch u.method {
+	case UUIDv7MethodRandom:
+		randA = uint64(randomInt64()) & 0xFFF
+	case UUIDv7MethodSubMs:
+		randA = uint64(now.UnixNano()%int64(time.Millisecond)) * 4096 / uint64(time.Millisecond)
+	case UUIDv7MethodCounter:
+		ms, randA = u.nextCounter(ms)
 	}
 
-	stats["total_table_size"] = tableStats.TotalTableSize
-	stats["data_size"] = tableStats.DataSize
-	stats["index_size"] = tableStats.IndexSize
-	stats["index_internal_pages"] = tableStats.InternalPages
-	stats["index_leaf_pages"] = tableStats.LeafPages
-	stats["index_density"] = tableStats.Density
-	stats["index_fragmentation"] = tableStats.Fragmentation
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[0:8], uint64(ms)<<16|0x7000|randA)
+	binary.BigEndian.PutUint64(b[8:16], uint64(randomInt64())&(1<<62-1)|0x8000000000000000)
+	return b
+}
 
-	// Calculate the ratio of internal pages to leaf pages
-	stats["index_internal_to_leaf_ratio"] = float64(tableStats.InternalPages) / float64(tableStats.LeafPages)
+// nextCounter returns the timestamp and counter for the counter method. The counter starts with its top bit clear,
+// which leaves at least 2048 increments before it overflows into the next millisecond.
+func (u *UUIDv7Generator) nextCounter(ms int64) (int64, uint64) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if ms > u.lastMs {
+		u.lastMs = ms
+		u.counter = uint16(randomInt64()) & 0x7FF
+	} else {
+		u.counter++
+		if u.counter > 0xFFF {
+			u.lastMs++
+			u.counter = 0
+		}
+	}
 
-	return stats, nil
+	return u.lastMs, uint64(u.counter)
 }
 
-func (u *UUIDv7Generator) InsertRecord(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "INSERT INTO uuidv7_table (id, n) VALUES ($1, $2)", u.Generate(), 1)
-	return err
+func (u *UUIDv7Generator) Name() string {
+	if u.method == UUIDv7MethodLibrary {
+		return "UUIDv7 - UUID"
+	}
+	return fmt.Sprintf("UUIDv7 (%s) - UUID", u.method)
+}
+
+func (u *UUIDv7Generator) Metadata() Metadata {
+	m := uuidMetadata()
+	m.RandomBits = 62
+	m.TimestampBits = 48
+	m.TimestampResolution = "ms"
+	m.KSortable = true
+
+	switch u.method {
+	case UUIDv7MethodRandom:
+		m.RandomBits = 74
+	case UUIDv7MethodSubMs:
+		m.TimestampBits = 60
+		m.TimestampResolution = uuidv7SubMsResolution
+	}
+
+	return m
 }
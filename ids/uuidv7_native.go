@@ -0,0 +1,72 @@
+package ids
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// UUIDv7NativeGenerator generates UUIDv7 IDs using the uuidv7() function built into PostgreSQL 18+
+type UUIDv7NativeGenerator struct {
+	*Table
+}
+
+var _ IDGenerator = (*UUIDv7NativeGenerator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "uuidv7-native",
+		Description:  "UUIDv7 from the uuidv7() function in PostgreSQL 18",
+		Capabilities: CapabilityDatabase,
+		New:          withoutOptions(NewUUIDv7NativeGenerator),
+	})
+}
+
+func NewUUIDv7NativeGenerator(table string) *UUIDv7NativeGenerator {
+	u := &UUIDv7NativeGenerator{}
+	u.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "UUID DEFAULT uuidv7()",
+		Setup:    u.CheckAvailable,
+		Stats: func(_ context.Context, _ *pgxpool.Pool, stats map[string]any) error {
+			// uuidv7() fills rand_a with the sub-millisecond time and keeps IDs increasing within a session
+			stats["uuidv7_method"] = UUIDv7MethodSubMs
+			return nil
+		},
+	})
+	return u
+}
+
+func (u *UUIDv7NativeGenerator) Generate() string {
+	// UUIDv7 is generated by the database
+	return ""
+}
+
+func (u *UUIDv7NativeGenerator) Name() string {
+	return "UUIDv7 (Native) - UUID"
+}
+
+func (u *UUIDv7NativeGenerator) Metadata() Metadata {
+	m := uuidMetadata()
+	m.RandomBits = 62
+	m.TimestampBits = 60
+	m.TimestampResolution = uuidv7SubMsResolution
+	m.KSortable = true
+	m.Source = SourceDatabase
+	return m
+}
+
+// CheckAvailable returns ErrUnavailable if the server does not ship uuidv7(), which was added in PostgreSQL 18
+func (u *UUIDv7NativeGenerator) CheckAvailable(ctx context.Context, pool *pgxpool.Pool) error {
+	version, err := ServerVersionNum(ctx, pool)
+	if err != nil {
+		return err
+	}
+
+	if version < 180000 {
+		return fmt.Errorf("%w: uuidv7() requires PostgreSQL 18 or later, server version is %d", ErrUnavailable, version)
+	}
+
+	return nil
+}
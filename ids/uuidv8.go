@@ -0,0 +1,257 @@
+package ids
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// uuidv8CustomBits is the number of bits left for custom fields once the version and variant are set
+const uuidv8CustomBits = 122
+
+// UUIDv8Layout describes how the 122 custom bits of a UUIDv8 are split, from the most significant bit:
+// a timestamp, a counter that increments within a timestamp tick, a node ID and random bits.
+// Any bits left over after the random bits are zero.
+type UUIDv8Layout struct {
+	TimestampBits int
+	Resolution    time.Duration
+	CounterBits   int
+	NodeBits      int
+	NodeID        uint64
+	RandomBits    int
+}
+
+// DefaultUUIDv8Layout mirrors UUIDv7: a 48-bit millisecond timestamp, a 12-bit counter and 62 random bits
+var DefaultUUIDv8Layout = UUIDv8Layout{
+	TimestampBits: 48,
+	Resolution:    time.Millisecond,
+	CounterBits:   12,
+	RandomBits:    62,
+}
+
+var uuidv8Resolutions = map[string]time.Duration{
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+	"ns": time.Nanosecond,
+}
+
+// resolutionName returns the option value of the resolution, e.g. "ms"
+func (l UUIDv8Layout) resolutionName() string {
+	for name, d := range uuidv8Resolutions {
+		if d == l.Resolution {
+			return name
+		}
+	}
+	return ""
+}
+
+func (l UUIDv8Layout) String() string {
+	return fmt.Sprintf("ts=%d%s, counter=%d, node=%d, random=%d", l.TimestampBits, l.resolutionName(), l.CounterBits, l.NodeBits, l.RandomBits)
+}
+
+// Validate returns an error if the layout's fields don't fit in a UUIDv8
+func (l UUIDv8Layout) Validate() error {
+	for name, bits := range map[string]int{"ts": l.TimestampBits, "counter": l.CounterBits, "node": l.NodeBits} {
+		if bits < 0 || bits > 64 {
+			return fmt.Errorf("uuidv8 %s bits must be between 0 and 64, got %d", name, bits)
+		}
+	}
+
+	if l.RandomBits < 0 {
+		return fmt.Errorf("uuidv8 random bits must not be negative, got %d", l.RandomBits)
+	}
+
+	if total := l.TimestampBits + l.CounterBits + l.NodeBits + l.RandomBits; total > uuidv8CustomBits {
+		return fmt.Errorf("uuidv8 layout uses %d bits, only %d are available", total, uuidv8CustomBits)
+	}
+
+	if l.NodeBits < 64 && l.NodeID >= 1<<l.NodeBits {
+		return fmt.Errorf("uuidv8 node ID must be between 0 and %d, got %d", uint64(1)<<l.NodeBits-1, l.NodeID)
+	}
+
+	if l.TimestampBits > 0 && l.Resolution <= 0 {
+		return fmt.Errorf("uuidv8 timestamp resolution must be one of s, ms, us or ns")
+	}
+
+	return nil
+}
+
+// UUIDv8Generator generates RFC 9562 UUIDv8 IDs with a custom layout
+type UUIDv8Generator struct {
+	*Table
+
+	layout UUIDv8Layout
+
+	mu       sync.Mutex
+	lastTick uint64
+	counter  uint64
+}
+
+var _ BinaryGenerator = (*UUIDv8Generator)(nil)
+
+func init() {
+	Register(Registration{
+		Key:          "uuidv8",
+		Description:  "RFC 9562 UUIDv8 with a configurable layout",
+		Capabilities: CapabilityClient | CapabilityEncodings | CapabilityOptions,
+		New:          withOptions(NewUUIDv8Generator),
+	})
+}
+
+// NewUUIDv8Generator returns a UUIDv8 generator. Supported options: ts (timestamp bits, default 48),
+// resolution (s, ms, us or ns, default ms), counter (counter bits, default 12), node (node bits, default 0),
+// nodeid (default 0) and random (random bits, defaults to the bits left over).
+func NewUUIDv8Generator(table string, opts Options) (*UUIDv8Generator, error) {
+	if err := opts.Validate("ts", "resolution", "counter", "node", "nodeid", "random"); err != nil {
+		return nil, err
+	}
+
+	layout := DefaultUUIDv8Layout
+
+	var err error
+	if layout.TimestampBits, err = opts.Int("ts", layout.TimestampBits); err != nil {
+		return nil, err
+	}
+	if layout.CounterBits, err = opts.Int("counter", layout.CounterBits); err != nil {
+		return nil, err
+	}
+	if layout.NodeBits, err = opts.Int("node", 0); err != nil {
+		return nil, err
+	}
+
+	nodeID, err := opts.Int("nodeid", 0)
+	if err != nil {
+		return nil, err
+	}
+	if nodeID < 0 {
+		return nil, fmt.Errorf("uuidv8 node ID must not be negative, got %d", nodeID)
+	}
+	layout.NodeID = uint64(nodeID)
+
+	resolution := opts.String("resolution", "ms")
+	layout.Resolution = uuidv8Resolutions[resolution]
+
+	// Random bits fill whatever the other fields leave over unless they are set explicitly
+	remaining := uuidv8CustomBits - layout.TimestampBits - layout.CounterBits - layout.NodeBits
+	if layout.RandomBits, err = opts.Int("random", remaining); err != nil {
+		return nil, err
+	}
+
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+
+	return NewUUIDv8GeneratorWithLayout(table, layout), nil
+}
+
+// NewUUIDv8GeneratorWithLayout returns a UUIDv8 generator for a layout that has already been validated
+func NewUUIDv8GeneratorWithLayout(table string, layout UUIDv8Layout) *UUIDv8Generator {
+	u := &UUIDv8Generator{layout: layout}
+	u.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "UUID",
+		Value:    func(uint64) any { return u.Generate() },
+		Stats: func(_ context.Context, _ *pgxpool.Pool, stats map[string]any) error {
+			stats["uuidv8_layout"] = u.layout.String()
+			return nil
+		},
+	})
+	return u
+}
+
+// uuidv8Payload accumulates the 122 custom bits of a UUIDv8, hi holds the top 58 bits
+type uuidv8Payload struct {
+	hi, lo uint64
+}
+
+func (p *uuidv8Payload) append(v uint64, bits int) {
+	for bits > 0 {
+		w := min(bits, 32)
+		bits -= w
+		chunk := (v >> bits) & (1<<w - 1)
+		p.hi = p.hi<<w | p.lo>>(64-w)
+		p.lo = p.lo<<w | chunk
+	}
+}
+
+// bytes spreads the payload around the version and variant bits
+func (p *uuidv8Payload) bytes() []byte {
+	a := p.hi >> 10                   // 48 bits before the version
+	b := (p.hi<<2 | p.lo>>62) & 0xFFF // 12 bits between the version and the variant
+	c := p.lo & (1<<62 - 1)           // 62 bits after the variant
+
+	b16 := make([]byte, 16)
+	binary.BigEndian.PutUint64(b16[0:8], a<<16|0x8000|b)
+	binary.BigEndian.PutUint64(b16[8:16], c|0x8000000000000000)
+	return b16
+}
+
+// next returns the timestamp tick and counter for the next ID. When the counter overflows it borrows the next
+// tick, so IDs stay ordered. Ticks wider than the timestamp field are truncated to their low bits.
+func (u *UUIDv8Generator) next() (uint64, uint64) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	var tick uint64
+	if u.layout.TimestampBits > 0 {
+		tick = uint64(time.Now().UnixNano() / int64(u.layout.Resolution))
+	}
+
+	if tick > u.lastTick {
+		u.lastTick = tick
+		u.counter = 0
+	} else if u.layout.CounterBits > 0 {
+		u.counter++
+		if u.layout.CounterBits < 64 && u.counter>>u.layout.CounterBits != 0 {
+			u.lastTick++
+			u.counter = 0
+		}
+	}
+
+	return u.lastTick, u.counter
+}
+
+func (u *UUIDv8Generator) GenerateBytes() []byte {
+	tick, counter := u.next()
+	l := u.layout
+
+	var p uuidv8Payload
+	p.append(tick, l.TimestampBits)
+	p.append(counter, l.CounterBits)
+	p.append(l.NodeID, l.NodeBits)
+
+	for bits := l.RandomBits; bits > 0; bits -= 64 {
+		w := min(bits, 64)
+		p.append(uint64(randomInt64()), w)
+	}
+
+	p.append(0, uuidv8CustomBits-l.TimestampBits-l.CounterBits-l.NodeBits-l.RandomBits)
+
+	return p.bytes()
+}
+
+func (u *UUIDv8Generator) Generate() string {
+	return uuid.Must(uuid.FromBytes(u.GenerateBytes())).String()
+}
+
+func (u *UUIDv8Generator) Name() string {
+	return fmt.Sprintf("UUIDv8 (%s) - UUID", u.layout)
+}
+
+func (u *UUIDv8Generator) Metadata() Metadata {
+	m := uuidMetadata()
+	m.RandomBits = float64(u.layout.RandomBits)
+	m.TimestampBits = u.layout.TimestampBits
+	if u.layout.TimestampBits > 0 {
+		// The timestamp comes first, so IDs sort by it
+		m.TimestampResolution = u.layout.resolutionName()
+		m.KSortable = true
+	}
+	return m
+}
@@ -0,0 +1,57 @@
+package ids
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUUIDv8VersionAndVariant(t *testing.T) {
+	layouts := []UUIDv8Layout{
+		DefaultUUIDv8Layout,
+		{TimestampBits: 64, Resolution: time.Nanosecond, CounterBits: 8, NodeBits: 10, NodeID: 7, RandomBits: 40},
+		{RandomBits: 122},
+	}
+
+	for _, layout := range layouts {
+		require.NoError(t, layout.Validate())
+
+		id, err := uuid.FromBytes(NewUUIDv8GeneratorWithLayout(TableNameFor("uuidv8"), layout).GenerateBytes())
+		require.NoError(t, err)
+		assert.Equal(t, byte(8), id.Version(), layout.String())
+		assert.Equal(t, byte(uuid.VariantRFC9562), id.Variant(), layout.String())
+	}
+}
+
+func TestUUIDv8IsOrderedWithTimestampAndCounter(t *testing.T) {
+	generator := NewUUIDv8GeneratorWithLayout(TableNameFor("uuidv8"), DefaultUUIDv8Layout)
+
+	prev := generator.GenerateBytes()
+	for i := 0; i < 10_000; i++ {
+		id := generator.GenerateBytes()
+		assert.Equal(t, -1, bytes.Compare(prev, id))
+		prev = id
+	}
+}
+
+func TestUUIDv8LayoutOptions(t *testing.T) {
+	generator, err := NewUUIDv8Generator(TableNameFor("uuidv8"), Options{"ts": "32", "resolution": "s", "node": "8", "nodeid": "3"})
+	require.NoError(t, err)
+	assert.Equal(t, "ts=32s, counter=12, node=8, random=70", generator.layout.String())
+
+	_, err = NewUUIDv8Generator(TableNameFor("uuidv8"), Options{"ts": "64", "random": "64"})
+	assert.Error(t, err)
+
+	_, err = NewUUIDv8Generator(TableNameFor("uuidv8"), Options{"node": "8", "nodeid": "256"})
+	assert.EqualError(t, err, "uuidv8 node ID must be between 0 and 255, got 256")
+
+	_, err = NewUUIDv8Generator(TableNameFor("uuidv8"), Options{"node": "8", "nodeid": "-1"})
+	assert.EqualError(t, err, "uuidv8 node ID must not be negative, got -1")
+
+	_, err = NewUUIDv8Generator(TableNameFor("uuidv8"), Options{"nodeid": "1"})
+	assert.EqualError(t, err, "uuidv8 node ID must be between 0 and 0, got 1")
+}
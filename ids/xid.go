@@ -1,88 +1,55 @@
 package ids
 
-import (
-	"context"
-	"fmt"
-
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/rs/xid"
-)
+import "github.com/rs/xid"
 
 // XIDGenerator generates XIDs
-type XIDGenerator struct{}
-
-var _ IDGenerator = (*XIDGenerator)(nil)
-
-func NewXIDGenerator() *XIDGenerator {
-	return &XIDGenerator{}
+type XIDGenerator struct {
+	*Table
 }
 
-func (x *XIDGenerator) Generate() string {
-	return xid.New().String()
-}
+var _ BinaryGenerator = (*XIDGenerator)(nil)
 
-func (x *XIDGenerator) Name() string {
-	return "XID - VARCHAR(20)"
+func init() {
+	Register(Registration{
+		Key:          "xid",
+		Description:  "12-byte globally unique XID",
+		Capabilities: CapabilityClient | CapabilityEncodings,
+		New:          withoutOptions(NewXIDGenerator),
+	})
 }
 
-func (x *XIDGenerator) CreateTable(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "CREATE TABLE IF NOT EXISTS xid_table (id VARCHAR(20) PRIMARY KEY, n BIGINT NOT NULL)")
-	return err
+func NewXIDGenerator(table string) *XIDGenerator {
+	x := &XIDGenerator{}
+	x.Table = NewTable(TableSpec{
+		Name:     table,
+		IDColumn: "VARCHAR(20)",
+		Value:    func(uint64) any { return x.Generate() },
+	})
+	return x
 }
 
-func (x *XIDGenerator) DropTable(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "DROP TABLE IF EXISTS xid_table")
-	return err
+func (x *XIDGenerator) Generate() string {
+	return xid.New().String()
 }
 
-func (x *XIDGenerator) BulkWriteRecords(ctx context.Context, pool *pgxpool.Pool, count uint64) error {
-	batch := &pgx.Batch{}
-	for i := uint64(1); i <= count; i++ {
-		batch.Queue("INSERT INTO xid_table (id, n) VALUES ($1, $2)", x.Generate(), i)
-	}
-	br := pool.SendBatch(ctx, batch)
-	return br.Close()
+func (x *XIDGenerator) GenerateBytes() []byte {
+	return xid.New().Bytes()
 }
 
-func (x *XIDGenerator) CollectStats(ctx context.Context, pool *pgxpool.Pool) (map[string]any, error) {
-	stats := make(map[string]any)
-
-	err := LoadPGStatTuple(ctx, pool)
-	if err != nil {
-		return nil, err
-	}
-
-	var tableStats TableStats
-
-	err = pool.QueryRow(ctx, fmt.Sprintf(fmtStatsQuery, "xid_table", "xid_table", "xid_table")).Scan(
-		&tableStats.TotalTableSize,
-		&tableStats.DataSize,
-		&tableStats.IndexSize,
-		&tableStats.InternalPages,
-		&tableStats.LeafPages,
-		&tableStats.Density,
-		&tableStats.Fragmentation,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	stats["total_table_size"] = tableStats.TotalTableSize
-	stats["data_size"] = tableStats.DataSize
-	stats["index_size"] = tableStats.IndexSize
-	stats["index_internal_pages"] = tableStats.InternalPages
-	stats["index_leaf_pages"] = tableStats.LeafPages
-	stats["index_density"] = tableStats.Density
-	stats["index_fragmentation"] = tableStats.Fragmentation
-
-	// Calculate the ratio of internal pages to leaf pages
-	stats["index_internal_to_leaf_ratio"] = float64(tableStats.InternalPages) / float64(tableStats.LeafPages)
-
-	return stats, nil
+func (x *XIDGenerator) Name() string {
+	return "XID - VARCHAR(20)"
 }
 
-func (x *XIDGenerator) InsertRecord(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "INSERT INTO xid_table (id, n) VALUES ($1, $2)", x.Generate(), 1)
-	return err
+func (x *XIDGenerator) Metadata() Metadata {
+	// The machine ID and process ID are fixed, the counter starts at a random value once per process
+	return Metadata{
+		Bits:                96,
+		TimestampBits:       32,
+		TimestampResolution: "s",
+		KSortable:           true,
+		Source:              SourceClient,
+		StringLength:        20,
+		Alphabet:            AlphabetBase32Hex,
+		Storage:             "VARCHAR(20)",
+	}
 }
@@ -1,5 +1,20 @@
 -- https://github.com/andrielfn/pg-ulid
+
+-- compareids:key ulid-pg
+-- compareids:name ULID (PG) - ULID
+-- compareids:column ulid
+-- compareids:default gen_ulid()
+-- compareids:meta bits 128
+-- compareids:meta random_bits 80
+-- compareids:meta timestamp_bits 48
+-- compareids:meta timestamp_resolution ms
+-- compareids:meta k_sortable true
+-- compareids:meta string_length 26
+-- compareids:meta alphabet crockford base32
+
+-- compareids:setup
 CREATE EXTENSION IF NOT EXISTS ulid with schema public;
+-- compareids:end
 
 drop function if exists test_pg_ulid_insert(count BIGINT);
 create or replace function test_pg_ulid_insert(count BIGINT) returns DOUBLE PRECISION as $$
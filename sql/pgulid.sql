@@ -16,9 +16,24 @@
 -- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 -- See the License for the specific language governing permissions and
 -- limitations under the License.
-CREATE EXTENSION IF NOT EXISTS pgcrypto with schema public;
+
+-- compareids:key ulid-db
+-- compareids:name ULID (DB) - VARCHAR(26)
+-- compareids:column VARCHAR(26)
+-- compareids:default generate_ulid()
+-- compareids:meta bits 128
+-- compareids:meta random_bits 80
+-- compareids:meta timestamp_bits 48
+-- compareids:meta timestamp_resolution ms
+-- compareids:meta k_sortable true
+-- compareids:meta string_length 26
+-- compareids:meta alphabet crockford base32
 
 drop function if exists generate_ulid();
+
+-- compareids:setup
+CREATE EXTENSION IF NOT EXISTS pgcrypto with schema public;
+
 CREATE OR REPLACE FUNCTION generate_ulid()RETURNS TEXT AS $$
 DECLARE
     -- Crockford's Base32
@@ -74,6 +89,7 @@ BEGIN
     RETURN output;
 END
 $$ LANGUAGE plpgsql VOLATILE;
+-- compareids:end
 
 drop function if exists test_pgulid_insert(count BIGINT);
 create or replace function test_pgulid_insert(count BIGINT) returns DOUBLE PRECISION as $$
@@ -0,0 +1,41 @@
+-- compareids:key random-bigint-db
+-- compareids:name Random (DB) - BIGINT
+-- compareids:column BIGINT
+-- compareids:default random_bigint()
+-- compareids:meta bits 64
+-- compareids:meta random_bits 64
+-- compareids:meta alphabet decimal
+
+-- compareids:setup
+CREATE EXTENSION IF NOT EXISTS pgcrypto with schema public;
+
+-- Returns a crypto-random 64-bit integer, from 8 bytes of gen_random_bytes
+create or replace function random_bigint() returns bigint as $$
+begin
+    return ('x' || encode(gen_random_bytes(8), 'hex'))::bit(64)::bigint;
+end $$ language plpgsql volatile;
+-- compareids:end
+
+drop function if exists test_random_bigint_insert(count BIGINT);
+create or replace function test_random_bigint_insert(count BIGINT) returns DOUBLE PRECISION as $$
+declare
+    v_start double precision;
+    v_end   double precision;
+begin
+    drop table if exists test_random_bigint;
+    create table test_random_bigint(id bigint primary key default random_bigint(), n bigint not null);
+    v_start := extract(epoch from clock_timestamp());
+    insert into test_random_bigint(n) select g.n from generate_series(1,count) as g(n);
+    v_end := extract(epoch from clock_timestamp());
+    raise notice 'Time taken to insert %s random BIGINT records: %s', count, v_end - v_start;
+    return v_end - v_start;
+end;
+$$ language plpgsql;
+
+select test_random_bigint_insert(100000) as t_100_000,
+       test_random_bigint_insert(1000000) as t_1_000_000,
+       test_random_bigint_insert(10000000) as t_10_000_000;
+
+select pg_size_pretty(pg_total_relation_size('test_random_bigint')) as total_table_size,
+       pg_size_pretty(pg_relation_size('test_random_bigint')) as data_size,
+       pg_size_pretty(pg_indexes_size('test_random_bigint')) as index_size;
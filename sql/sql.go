@@ -0,0 +1,32 @@
+// Package sql embeds the SQL scripts in this directory and registers the generators they define.
+// Scripts annotated with compareids directives define DB-side generators, see ids.ParseSQLDefinitions.
+package sql
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/jirevwe/compareids/ids"
+)
+
+//go:embed *.sql
+var Files embed.FS
+
+func init() {
+	definitions, err := ids.ParseSQLDefinitions(Files)
+	if err != nil {
+		// The files are embedded at build time, so this can only be fixed by editing them
+		panic(fmt.Sprintf("sql: %v", err))
+	}
+
+	for _, def := range definitions {
+		ids.Register(ids.Registration{
+			Key:          def.Key,
+			Description:  fmt.Sprintf("Defined in sql/%s", def.File),
+			Capabilities: ids.CapabilityDatabase,
+			New: func(table string, _ ids.Options) (ids.IDGenerator, error) {
+				return ids.NewSQLGenerator(table, def), nil
+			},
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package sql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jirevwe/compareids/ids"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedSQLDefinitions(t *testing.T) {
+	definitions, err := ids.ParseSQLDefinitions(Files)
+	require.NoError(t, err)
+
+	keys := make(map[string]ids.SQLDefinition)
+	for _, def := range definitions {
+		keys[def.Key] = def
+	}
+
+	require.Contains(t, keys, "ulid-db")
+	assert.Equal(t, "ULID (DB) - VARCHAR(26)", keys["ulid-db"].Name)
+	assert.Contains(t, keys["ulid-db"].Setup, "CREATE OR REPLACE FUNCTION generate_ulid()")
+	assert.NotContains(t, keys["ulid-db"].Setup, "test_pgulid_insert")
+
+	require.Contains(t, keys, "uuidv7-db")
+	assert.Equal(t, 2, strings.Count(keys["uuidv7-db"].Setup, "create or replace function uuid7("))
+	assert.Equal(t, ids.UUIDv7MethodSubMs, keys["uuidv7-db"].Stats["uuidv7_method"])
+
+	require.Contains(t, keys, "ulid-pg")
+}
+
+func TestEmbeddedSQLGeneratorsAreRegistered(t *testing.T) {
+	for _, key := range []string{"ulid-db", "uuidv7-db", "ulid-pg", "random-bigint-db"} {
+		r, ok := ids.Lookup(key)
+		require.True(t, ok, key)
+		assert.True(t, r.Capabilities.Has(ids.CapabilityDatabase))
+	}
+}
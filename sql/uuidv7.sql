@@ -31,6 +31,21 @@
  * MIT License.
  *
  */
+
+-- compareids:key uuidv7-db
+-- compareids:name UUIDv7 (DB) - UUID
+-- compareids:column UUID
+-- compareids:default uuid7()
+-- compareids:stat uuidv7_method subms
+-- compareids:meta bits 128
+-- compareids:meta random_bits 62
+-- compareids:meta timestamp_bits 60
+-- compareids:meta timestamp_resolution 244ns
+-- compareids:meta k_sortable true
+-- compareids:meta string_length 36
+-- compareids:meta alphabet hex
+
+-- compareids:setup
 create or replace function uuid7() returns uuid as $$
 declare
 begin
@@ -73,6 +88,7 @@ begin
     return (v_unix_t_hex || v_rand_a_hex || v_rand_b_hex)::uuid;
 
 end $$ language plpgsql;
+-- compareids:end
 
 -- select uuid7() uuid, clock_timestamp()-statement_timestamp() time_taken;
 